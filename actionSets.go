@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actionSetCallActor     = "call_actions"
+	actionSetCallAttribute = "set"
+)
+
+// actionListFields returns the keys of all attributes of the given
+// actor type documented to contain nested action lists
+func actionListFields(actorType string) (keys []string) {
+	availableActorDocsLock.RLock()
+	defer availableActorDocsLock.RUnlock()
+
+	for _, doc := range availableActorDocs {
+		if doc.Type != actorType {
+			continue
+		}
+
+		for _, f := range doc.Fields {
+			if f.Type == plugins.ActionDocumentationFieldTypeActionList {
+				keys = append(keys, f.Key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// getActionSet returns the actions of the named action set from the
+// currently loaded config
+func getActionSet(name string) ([]*plugins.RuleAction, error) {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	actions, ok := config.ActionSets[name]
+	if !ok {
+		return nil, fmt.Errorf("action set %q not found", name)
+	}
+
+	return actions, nil
+}
+
+// checkActionSetReferences walks the given actions (including nested
+// action lists) and ensures all called action sets exist and are not
+// called recursively
+func (c configFile) checkActionSetReferences(actions []*plugins.RuleAction, callStack []string) error {
+	for _, a := range actions {
+		if err := c.checkActionSetReferences(a.OnError, callStack); err != nil {
+			return err
+		}
+
+		if a.Attributes == nil {
+			// Nothing to reference, invalid actions are reported by
+			// the actor validation
+			continue
+		}
+
+		if a.Type == actionSetCallActor {
+			name, err := a.Attributes.String(actionSetCallAttribute)
+			if err != nil {
+				// Reported by the actor validation
+				continue
+			}
+
+			set, ok := c.ActionSets[name]
+			if !ok {
+				return fmt.Errorf("action set %q not found", name)
+			}
+
+			if slices.Contains(callStack, name) {
+				return fmt.Errorf("action set %q is called recursively", name)
+			}
+
+			if err = c.checkActionSetReferences(set, append(slices.Clone(callStack), name)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, key := range actionListFields(a.Type) {
+			nested, err := plugins.ParseRuleActions(a.Attributes, key)
+			if err != nil {
+				// Reported by the actor validation
+				continue
+			}
+
+			if err = c.checkActionSetReferences(nested, callStack); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c configFile) validateActionSets() error {
+	for name, actions := range c.ActionSets {
+		if err := validateActions(actions); err != nil {
+			return fmt.Errorf("validating action set %q: %w", name, err)
+		}
+
+		if err := c.checkActionSetReferences(actions, []string{name}); err != nil {
+			return fmt.Errorf("checking action set %q: %w", name, err)
+		}
+	}
+
+	for _, r := range c.Rules {
+		if err := c.checkActionSetReferences(r.Actions, nil); err != nil {
+			return fmt.Errorf("checking rule %q: %w", r.MatcherID(), err)
+		}
+	}
+
+	return nil
+}
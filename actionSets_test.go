@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+func TestValidateActionSets(t *testing.T) {
+	callSet := func(name string) *plugins.RuleAction {
+		return &plugins.RuleAction{
+			Type:       actionSetCallActor,
+			Attributes: fieldcollection.FieldCollectionFromData(map[string]any{"set": name}),
+		}
+	}
+
+	stop := &plugins.RuleAction{Type: "stopexec", Attributes: fieldcollection.FieldCollectionFromData(map[string]any{"when": "true"})}
+
+	c := configFile{
+		ActionSets: map[string][]*plugins.RuleAction{
+			"inner": {stop},
+			"outer": {callSet("inner")},
+		},
+		Rules: []*plugins.Rule{{Actions: []*plugins.RuleAction{callSet("outer")}}},
+	}
+	require.NoError(t, c.validateActionSets())
+
+	c.Rules[0].Actions = []*plugins.RuleAction{callSet("unknown")}
+	assert.ErrorContains(t, c.validateActionSets(), `action set "unknown" not found`)
+
+	c.Rules[0].Actions = nil
+	c.ActionSets["inner"] = []*plugins.RuleAction{{
+		Type: "conditional",
+		Attributes: fieldcollection.FieldCollectionFromData(map[string]any{
+			"when": "true",
+			"then": []any{map[string]any{"type": actionSetCallActor, "attributes": map[string]any{"set": "outer"}}},
+		}),
+	}}
+	assert.ErrorContains(t, c.validateActionSets(), "called recursively")
+}
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/Luzifer/go_helpers/backoff"
 	"github.com/Luzifer/go_helpers/fieldcollection"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/irc.v4"
 
 	"github.com/Luzifer/twitch-bot/v3/internal/locker"
+	"github.com/Luzifer/twitch-bot/v3/internal/service/ruleexec"
 	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
@@ -44,10 +47,61 @@ func registerAction(name string, acf plugins.ActorCreationFunc) {
 	availableActions[name] = acf
 }
 
-func triggerAction(c *irc.Client, m *irc.Message, rule *plugins.Rule, ra *plugins.RuleAction, eventData *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
-	availableActionsLock.RLock()
-	defer availableActionsLock.RUnlock()
+func executeActions(c *irc.Client, m *irc.Message, rule *plugins.Rule, actions []*plugins.RuleAction, eventData *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	return executeActionsWithHook(c, m, rule, actions, eventData, nil)
+}
 
+// executeActionsWithHook executes the actions like executeActions
+// does and passes the outcome of every action into the hook if set
+func executeActionsWithHook(
+	c *irc.Client, m *irc.Message, rule *plugins.Rule, actions []*plugins.RuleAction, eventData *fieldcollection.FieldCollection,
+	hook func(ra *plugins.RuleAction, took time.Duration, err error),
+) (preventCooldown bool, err error) {
+	for _, a := range actions {
+		start := time.Now()
+		apc, err := triggerActionWithRetry(c, m, rule, a, eventData)
+		if hook != nil {
+			hook(a, time.Since(start), err)
+		}
+		if err != nil && len(a.OnError) > 0 && !errors.Is(err, plugins.ErrStopRuleExecution) {
+			apc, err = executeOnErrorActions(c, m, rule, a, eventData, err, hook)
+		}
+		if err != nil {
+			// Stop executing the actions stack on the first error and
+			// hand it to the caller which decides whether the error was
+			// a graceful stop of the execution
+			return preventCooldown || apc, err
+		}
+
+		preventCooldown = preventCooldown || apc
+	}
+
+	return preventCooldown, nil
+}
+
+// executeOnErrorActions runs the on_error actions of the failed
+// action with the error passed in the event data. When they succeed
+// the rule execution is stopped gracefully without setting cooldowns
+// as the failed action was not executed.
+func executeOnErrorActions(
+	c *irc.Client, m *irc.Message, rule *plugins.Rule, ra *plugins.RuleAction, eventData *fieldcollection.FieldCollection, actionErr error,
+	hook func(ra *plugins.RuleAction, took time.Duration, err error),
+) (preventCooldown bool, err error) {
+	errEventData := fieldcollection.NewFieldCollection()
+	if eventData != nil {
+		errEventData.SetFromData(eventData.Data())
+	}
+	errEventData.Set("error", actionErr.Error())
+	errEventData.Set("error_action", ra.Type)
+
+	if _, err = executeActionsWithHook(c, m, rule, ra.OnError, errEventData, hook); err != nil && !errors.Is(err, plugins.ErrStopRuleExecution) {
+		return true, fmt.Errorf("executing on_error actions of %s: %w (original error: %w)", ra.Type, err, actionErr)
+	}
+
+	return true, plugins.ErrStopRuleExecution
+}
+
+func triggerAction(c *irc.Client, m *irc.Message, rule *plugins.Rule, ra *plugins.RuleAction, eventData *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
 	a, err := getActorByName(ra.Type)
 	if err != nil {
 		return false, fmt.Errorf("getting actor: %w", err)
@@ -72,69 +126,134 @@ func triggerAction(c *irc.Client, m *irc.Message, rule *plugins.Rule, ra *plugin
 	return apc, nil
 }
 
+// triggerActionWithRetry executes the action and retries it as
+// defined in its retry policy. Asynchronous actions are not retried
+// as their errors are not available.
+func triggerActionWithRetry(c *irc.Client, m *irc.Message, rule *plugins.Rule, ra *plugins.RuleAction, eventData *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	if ra.Retry == nil || ra.Retry.Count < 1 {
+		return triggerAction(c, m, rule, ra, eventData)
+	}
+
+	bo := backoff.NewBackoff().WithMaxIterations(uint64(ra.Retry.Count) + 1) //#nosec:G115 // Count is checked to be positive
+	if ra.Retry.Delay > 0 {
+		bo = bo.WithMinIterationTime(ra.Retry.Delay)
+	}
+
+	err = bo.Retry(func() error {
+		apc, err := triggerAction(c, m, rule, ra, eventData)
+		preventCooldown = apc
+
+		if errors.Is(err, plugins.ErrStopRuleExecution) {
+			// Graceful stop, not an error to retry
+			return backoff.NewErrCannotRetry(err)
+		}
+
+		if err != nil {
+			log.WithField("actor", ra.Type).WithError(err).Debug("Action failed, retrying")
+		}
+
+		return err
+	})
+	if err != nil {
+		return preventCooldown, fmt.Errorf("retrying action: %w", err)
+	}
+
+	return preventCooldown, nil
+}
+
 func handleMessage(c *irc.Client, m *irc.Message, event *string, eventData *fieldcollection.FieldCollection) {
 	// Send events to registered handlers
 	if event != nil {
 		go notifyEventHandlers(*event, eventData)
 	}
 
-	matchingRules := config.GetMatchingRules(m, event, eventData)
-	for i := range matchingRules {
-		go handleMessageRuleExecution(c, m, matchingRules[i], eventData)
+	groupedRules := map[string][]*plugins.Rule{}
+
+	for _, r := range config.GetMatchingRules(m, event, eventData) {
+		if r.Group == "" {
+			go handleMessageRuleExecution(c, m, event, r, eventData)
+			continue
+		}
+
+		groupedRules[r.Group] = append(groupedRules[r.Group], r)
+	}
+
+	for _, rules := range groupedRules {
+		go handleMessageGroupExecution(c, m, event, rules, eventData)
+	}
+}
+
+// handleMessageGroupExecution executes the first rule of the group
+// not being on cooldown. The rules are expected to be ordered by their
+// priority.
+func handleMessageGroupExecution(c *irc.Client, m *irc.Message, event *string, rules []*plugins.Rule, eventData *fieldcollection.FieldCollection) {
+	for _, r := range rules {
+		if handleMessageRuleExecution(c, m, event, r, eventData) {
+			return
+		}
 	}
 }
 
-func handleMessageRuleExecution(c *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection) {
+// handleMessageRuleExecution executes the actions of the rule and
+// reports whether the rule was executed or was skipped by its cooldowns
+func handleMessageRuleExecution(c *irc.Client, m *irc.Message, event *string, r *plugins.Rule, eventData *fieldcollection.FieldCollection) bool {
 	lockKey := r.CooldownLockKey(m, eventData)
 	locker.LockByKey(lockKey)
 	defer locker.UnlockByKey(lockKey)
 
 	if !r.CanExecuteCooldowns(m, timerService, eventData) {
-		return
+		return false
 	}
 
 	var (
-		ruleEventData   = fieldcollection.NewFieldCollection()
-		executionError  bool
-		preventCooldown bool
+		ruleEventData  = fieldcollection.NewFieldCollection()
+		executionError bool
 	)
 
 	if eventData != nil {
 		ruleEventData.SetFromData(eventData.Data())
 	}
 
-ActionsLoop:
-	for _, a := range r.Actions {
-		apc, err := triggerAction(c, m, r, a, ruleEventData)
-		switch {
-		case err == nil:
-			// Rule execution did not cause an error, we store the
-			// cooldown modifier and continue
+	var eventName string
+	if event != nil {
+		eventName = *event
+	}
 
-			preventCooldown = preventCooldown || apc
-			continue ActionsLoop
+	execution := ruleexec.Execution{
+		RuleUUID:   r.MatcherID(),
+		ExecutedAt: time.Now(),
+		Channel:    plugins.DeriveChannel(m, eventData),
+		User:       plugins.DeriveUser(m, eventData),
+		Event:      eventName,
+	}
 
-		case errors.Is(err, plugins.ErrStopRuleExecution):
-			// Action has asked to stop executing this rule so we store
-			// the cooldown modifier and stop executing the actions stack
-			// Action experienced an error: We don't store the cooldown
-			// state of this action and stop executing the actions stack
-			// for this rule
+	preventCooldown, err := executeActionsWithHook(c, m, r, r.Actions, ruleEventData, execution.AddAction)
+	switch {
+	case err == nil:
+		// Rule execution did not cause an error, the cooldown modifier
+		// has been collected from all actions
 
-			preventCooldown = preventCooldown || apc
-			break ActionsLoop
+	case errors.Is(err, plugins.ErrStopRuleExecution):
+		// Action has asked to stop executing this rule so we keep
+		// the cooldown modifier and do not set an error state
 
-		default:
-			// Break execution for this rule when one action fails
-			// Lock command
+	default:
+		// Break execution for this rule when one action fails
+		// Lock command
 
-			executionError = true
-			log.WithError(err).Error("Unable to trigger action")
-			break ActionsLoop
-		}
+		executionError = true
+		execution.Error = err.Error()
+		log.WithError(err).Error("Unable to trigger action")
 	}
 
 	if !preventCooldown && !executionError {
 		r.SetCooldown(timerService, m, eventData)
+		execution.CooldownSet = true
 	}
+
+	if err = ruleExecService.Record(execution); err != nil {
+		log.WithError(err).Error("Unable to record rule execution")
+	}
+
+	return true
 }
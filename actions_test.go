@@ -16,11 +16,15 @@ import (
 )
 
 type testExecutionActor struct {
-	exec func(*irc.Message) (bool, error)
-	name string
+	exec    func(*irc.Message) (bool, error)
+	name    string
+	onEvent func(*fieldcollection.FieldCollection)
 }
 
-func (a testExecutionActor) Execute(_ *irc.Client, m *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection, _ *fieldcollection.FieldCollection) (bool, error) {
+func (a testExecutionActor) Execute(_ *irc.Client, m *irc.Message, _ *plugins.Rule, eventData *fieldcollection.FieldCollection, _ *fieldcollection.FieldCollection) (bool, error) {
+	if a.onEvent != nil {
+		a.onEvent(eventData)
+	}
 	return a.exec(m)
 }
 
@@ -69,7 +73,7 @@ func TestHandleMessageRuleExecutionAppliesRuleCooldownOnce(t *testing.T) {
 	for range 2 {
 		go func() {
 			defer wg.Done()
-			handleMessageRuleExecution(nil, msg, rule, nil)
+			handleMessageRuleExecution(nil, msg, nil, rule, nil)
 		}()
 	}
 
@@ -101,7 +105,7 @@ func TestHandleMessageRuleExecutionAppliesChannelCooldownOncePerChannel(t *testi
 	for range 2 {
 		go func() {
 			defer wg.Done()
-			handleMessageRuleExecution(nil, msg, rule, nil)
+			handleMessageRuleExecution(nil, msg, nil, rule, nil)
 		}()
 	}
 
@@ -134,12 +138,12 @@ func TestHandleMessageRuleExecutionAllowsChannelCooldownAcrossChannels(t *testin
 
 	go func() {
 		defer wg.Done()
-		handleMessageRuleExecution(nil, msgA, rule, nil)
+		handleMessageRuleExecution(nil, msgA, nil, rule, nil)
 	}()
 
 	go func() {
 		defer wg.Done()
-		handleMessageRuleExecution(nil, msgB, rule, nil)
+		handleMessageRuleExecution(nil, msgB, nil, rule, nil)
 	}()
 
 	wg.Wait()
@@ -147,6 +151,31 @@ func TestHandleMessageRuleExecutionAllowsChannelCooldownAcrossChannels(t *testin
 	require.Equal(t, int32(2), executed.Load())
 }
 
+func TestHandleMessageGroupExecutionSkipsRulesOnCooldown(t *testing.T) {
+	var executed []string
+
+	recordingRule := func(uuid string) *plugins.Rule {
+		return testExecutionRule(registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+			executed = append(executed, uuid)
+			return false, nil
+		}), uuid)
+	}
+
+	high := recordingRule("group-high")
+	high.Cooldown = func(d time.Duration) *time.Duration { return &d }(time.Minute)
+	low := recordingRule("group-low")
+
+	msg := irc.MustParseMessage(":amy!amy@foo.example.com PRIVMSG #mychannel :!test")
+
+	// Highest priority rule is executed, the other one is skipped
+	handleMessageGroupExecution(nil, msg, nil, []*plugins.Rule{high, low}, nil)
+	require.Equal(t, []string{"group-high"}, executed)
+
+	// Highest priority rule is on cooldown, the next one is executed
+	handleMessageGroupExecution(nil, msg, nil, []*plugins.Rule{high, low}, nil)
+	require.Equal(t, []string{"group-high", "group-low"}, executed)
+}
+
 func TestHandleMessageRuleExecutionSkipsCooldownWhenPrevented(t *testing.T) {
 	actionName := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
 		return true, nil
@@ -156,7 +185,7 @@ func TestHandleMessageRuleExecutionSkipsCooldownWhenPrevented(t *testing.T) {
 	rule.Cooldown = func(d time.Duration) *time.Duration { return &d }(time.Minute)
 	msg := irc.MustParseMessage(":amy!amy@foo.example.com PRIVMSG #mychannel :!test")
 
-	handleMessageRuleExecution(nil, msg, rule, nil)
+	handleMessageRuleExecution(nil, msg, nil, rule, nil)
 
 	inCooldown, err := timerService.InCooldown(plugins.TimerTypeCooldown, "", rule.MatcherID())
 	require.NoError(t, err)
@@ -172,9 +201,123 @@ func TestHandleMessageRuleExecutionSkipsCooldownOnActionError(t *testing.T) {
 	rule.Cooldown = func(d time.Duration) *time.Duration { return &d }(time.Minute)
 	msg := irc.MustParseMessage(":amy!amy@foo.example.com PRIVMSG #mychannel :!test")
 
-	handleMessageRuleExecution(nil, msg, rule, nil)
+	handleMessageRuleExecution(nil, msg, nil, rule, nil)
 
 	inCooldown, err := timerService.InCooldown(plugins.TimerTypeCooldown, "", rule.MatcherID())
 	require.NoError(t, err)
 	require.False(t, inCooldown)
+
+	execs, err := ruleExecService.ListExecutions(rule.MatcherID(), 10)
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	require.Equal(t, "#mychannel", execs[0].Channel)
+	require.Equal(t, "amy", execs[0].User)
+	require.False(t, execs[0].CooldownSet)
+	require.Len(t, execs[0].Actions, 1)
+	require.Equal(t, actionName, execs[0].Actions[0].Type)
+	require.Contains(t, execs[0].Actions[0].Error, "boom")
+}
+
+func TestExecuteActionsStopsAtFirstError(t *testing.T) {
+	var executed atomic.Int32
+
+	okAction := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+		executed.Add(1)
+		return true, nil
+	})
+	stopAction := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+		executed.Add(1)
+		return false, plugins.ErrStopRuleExecution
+	})
+
+	preventCooldown, err := executeActions(nil, nil, &plugins.Rule{}, []*plugins.RuleAction{
+		{Type: okAction, Attributes: fieldcollection.NewFieldCollection()},
+		{Type: stopAction, Attributes: fieldcollection.NewFieldCollection()},
+		{Type: okAction, Attributes: fieldcollection.NewFieldCollection()},
+	}, fieldcollection.NewFieldCollection())
+
+	require.ErrorIs(t, err, plugins.ErrStopRuleExecution)
+	require.True(t, preventCooldown)
+	require.Equal(t, int32(2), executed.Load())
+}
+
+func TestExecuteActionsRetriesFailingAction(t *testing.T) {
+	var executed atomic.Int32
+
+	flakyAction := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+		if executed.Add(1) < 3 {
+			return false, errors.New("temporary failure")
+		}
+		return false, nil
+	})
+
+	_, err := executeActions(nil, nil, &plugins.Rule{}, []*plugins.RuleAction{
+		{
+			Type:       flakyAction,
+			Attributes: fieldcollection.NewFieldCollection(),
+			Retry:      &plugins.RuleActionRetry{Count: 2, Delay: time.Millisecond},
+		},
+	}, fieldcollection.NewFieldCollection())
+
+	require.NoError(t, err)
+	require.Equal(t, int32(3), executed.Load())
+}
+
+func TestHandleMessageRuleExecutionRunsOnErrorActions(t *testing.T) {
+	var (
+		executed  atomic.Int32
+		errorText string
+	)
+
+	failAction := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+		executed.Add(1)
+		return false, errors.New("twitch is down")
+	})
+	followingAction := registerTestExecutionActor(t, func(*irc.Message) (bool, error) {
+		t.Error("action after failed action was executed")
+		return false, nil
+	})
+
+	fallbackAction := fmt.Sprintf("test-execution-fallback-%d", time.Now().UnixNano())
+	registerAction(fallbackAction, func() plugins.Actor {
+		return testExecutionActor{
+			exec: func(*irc.Message) (bool, error) { return false, nil },
+			name: fallbackAction,
+			onEvent: func(evt *fieldcollection.FieldCollection) {
+				errorText = evt.MustString("error", nil)
+			},
+		}
+	})
+
+	rule := &plugins.Rule{
+		UUID:     "on-error-fallback",
+		Cooldown: func(d time.Duration) *time.Duration { return &d }(time.Minute),
+		Actions: []*plugins.RuleAction{
+			{
+				Type:       failAction,
+				Attributes: fieldcollection.NewFieldCollection(),
+				Retry:      &plugins.RuleActionRetry{Count: 1, Delay: time.Millisecond},
+				OnError: []*plugins.RuleAction{
+					{Type: fallbackAction, Attributes: fieldcollection.NewFieldCollection()},
+				},
+			},
+			{Type: followingAction, Attributes: fieldcollection.NewFieldCollection()},
+		},
+	}
+	msg := irc.MustParseMessage(":amy!amy@foo.example.com PRIVMSG #mychannel :!test")
+
+	handleMessageRuleExecution(nil, msg, nil, rule, nil)
+
+	require.Equal(t, int32(2), executed.Load())
+	require.Contains(t, errorText, "twitch is down")
+
+	inCooldown, err := timerService.InCooldown(plugins.TimerTypeCooldown, "", rule.MatcherID())
+	require.NoError(t, err)
+	require.False(t, inCooldown)
+
+	execs, err := ruleExecService.ListExecutions(rule.MatcherID(), 10)
+	require.NoError(t, err)
+	require.Len(t, execs, 1)
+	require.Empty(t, execs[0].Error)
+	require.Len(t, execs[0].Actions, 2)
 }
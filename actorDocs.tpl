@@ -5,6 +5,9 @@ title: Available Actions
 > [!TIP]
 > All these actions can be executed by your bot as soon as you add them to rules. Read their documentation to learn how to master them.
 
+> [!NOTE]
+> Every action can define a `retry` policy and `on_error` actions (see the rule actions in the config-file syntax). When the `on_error` actions succeed the remaining actions of the rule are skipped and no cooldown is set: the rule execution stops as if an action had asked to stop it.
+
 {{ range .Actors }}
 ## {{ .Name }}
 
@@ -17,6 +20,10 @@ title: Available Actions
 {{- range .Fields }}
     # {{ .Description }}
     # Optional: {{ .Optional }}
+{{- if eq .Type "actionlist" }}
+    # Type:     list of actions (same format as the rule actions)
+    {{ .Key }}: []{{ if .DefaultComment }} # {{ .DefaultComment }}{{ end }}
+{{- end }}
 {{- if eq .Type "bool" }}
     # Type:     {{ .Type }}{{ if .SupportTemplate }} (Supports Templating){{ end }}
     {{ .Key }}: {{ eq .Default "true" }}{{ if .DefaultComment }} # {{ .DefaultComment }}{{ end }}
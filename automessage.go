@@ -10,9 +10,10 @@ import (
 
 	"github.com/Luzifer/go_helpers/fieldcollection"
 	"github.com/mitchellh/hashstructure/v2"
-	"github.com/robfig/cron/v3"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
 type autoMessage struct {
@@ -36,7 +37,7 @@ type autoMessage struct {
 	lock sync.RWMutex
 }
 
-var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+var cronParser = plugins.CronParser
 
 func (a *autoMessage) CanSend() bool {
 	a.lock.RLock()
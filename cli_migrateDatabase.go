@@ -31,6 +31,7 @@ func init() {
 			// Core functions cannot register themselves, we take that for them
 			registerDatabaseCopyFunc("core-values", db.CopyDatabase)
 			registerDatabaseCopyFunc("permissions", accessService.CopyDatabase)
+			registerDatabaseCopyFunc("rule-executions", ruleExecService.CopyDatabase)
 			registerDatabaseCopyFunc("timers", timerService.CopyDatabase)
 
 			targetDB, err := database.New(args[1], args[2], cfg.StorageEncryptionPass)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Luzifer/go_helpers/cli"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/service/access"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+)
+
+func init() {
+	cliTool.Add(cli.RegistryEntry{
+		Name:        "rule-dry-run",
+		Description: "Evaluates all rules against a synthetic message or event (JSON file, use - for stdin) and reports why each matcher passed or failed",
+		Params:      []string{"<request file>"},
+		Run: func(args []string) (err error) {
+			if len(args) < 2 { //nolint:mnd // Just a count of parameters
+				return errors.New("usage: twitch-bot rule-dry-run <request file>")
+			}
+
+			var input io.Reader = os.Stdin
+			if args[1] != "-" {
+				f, err := os.Open(args[1]) //#nosec:G304 // This is intended to open a variable file
+				if err != nil {
+					return fmt.Errorf("opening request file: %w", err)
+				}
+				defer func() {
+					if err := f.Close(); err != nil {
+						logrus.WithError(err).Error("closing request file (leaked fd)")
+					}
+				}()
+
+				input = f
+			}
+
+			var req ruleDryRunRequest
+			if err = json.NewDecoder(input).Decode(&req); err != nil {
+				return fmt.Errorf("decoding request: %w", err)
+			}
+
+			if err = loadConfig(cfg.Config); err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			if twitchClient, err = accessService.GetBotTwitchClient(access.ClientConfig{
+				TwitchClient:       cfg.TwitchClient,
+				TwitchClientSecret: cfg.TwitchClientSecret,
+			}); err != nil {
+				twitchClient = twitch.New(cfg.TwitchClient, cfg.TwitchClientSecret, "", "")
+			}
+
+			res, err := dryRunRules(req)
+			if err != nil {
+				return fmt.Errorf("running dry-run: %w", err)
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err = enc.Encode(res); err != nil {
+				return fmt.Errorf("printing results: %w", err)
+			}
+
+			return nil
+		},
+	})
+}
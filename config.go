@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"gopkg.in/irc.v4"
 	"gopkg.in/yaml.v3"
 
+	"github.com/Luzifer/twitch-bot/v3/internal/service/ruleexec"
 	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
@@ -38,25 +40,33 @@ type (
 		Token   string   `json:"token" yaml:"-"`
 	}
 
+	configExecutionHistory struct {
+		MaxAge     time.Duration `yaml:"max_age"`
+		MaxEntries int64         `yaml:"max_entries"`
+	}
+
 	configFileVersioner struct {
 		ConfigVersion int64 `yaml:"config_version"`
 	}
 
 	configFile struct {
-		AuthTokens           map[string]configAuthToken `yaml:"auth_tokens"`
-		AutoMessages         []*autoMessage             `yaml:"auto_messages"`
-		BotEditors           []string                   `yaml:"bot_editors"`
-		Channels             []string                   `yaml:"channels"`
-		GitTrackConfig       bool                       `yaml:"git_track_config"`
-		HTTPListen           string                     `yaml:"http_listen"`
-		PermitAllowModerator bool                       `yaml:"permit_allow_moderator"`
-		PermitTimeout        time.Duration              `yaml:"permit_timeout"`
-		RawLog               string                     `yaml:"raw_log"`
-		ModuleConfig         plugins.ModuleConfig       `yaml:"module_config"`
-		Rules                []*plugins.Rule            `yaml:"rules"`
-		Variables            map[string]any             `yaml:"variables"`
-
-		rawLogWriter io.WriteCloser
+		ActionSets           map[string][]*plugins.RuleAction `yaml:"action_sets"`
+		AuthTokens           map[string]configAuthToken       `yaml:"auth_tokens"`
+		AutoMessages         []*autoMessage                   `yaml:"auto_messages"`
+		BotEditors           []string                         `yaml:"bot_editors"`
+		Channels             []string                         `yaml:"channels"`
+		GitTrackConfig       bool                             `yaml:"git_track_config"`
+		HTTPListen           string                           `yaml:"http_listen"`
+		PermitAllowModerator bool                             `yaml:"permit_allow_moderator"`
+		PermitTimeout        time.Duration                    `yaml:"permit_timeout"`
+		RawLog               string                           `yaml:"raw_log"`
+		ModuleConfig         plugins.ModuleConfig             `yaml:"module_config"`
+		ExecutionHistory     configExecutionHistory           `yaml:"execution_history"`
+		Rules                []*plugins.Rule                  `yaml:"rules"`
+		Variables            map[string]any                   `yaml:"variables"`
+
+		channelTimezones plugins.ChannelTimezones
+		rawLogWriter     io.WriteCloser
 
 		configFileVersioner `yaml:",inline"`
 	}
@@ -73,7 +83,11 @@ var (
 
 func newConfigFile() *configFile {
 	return &configFile{
-		AuthTokens:    make(map[string]configAuthToken),
+		AuthTokens: make(map[string]configAuthToken),
+		ExecutionHistory: configExecutionHistory{
+			MaxAge:     ruleexec.DefaultMaxAge,
+			MaxEntries: ruleexec.DefaultMaxEntries,
+		},
 		PermitTimeout: time.Minute,
 	}
 }
@@ -101,6 +115,14 @@ func loadConfig(filename string) error {
 		return fmt.Errorf("running load-checks on config: %w", err)
 	}
 
+	if err = tmpConfig.compileRuleMatchers(); err != nil {
+		return fmt.Errorf("compiling rule matchers: %w", err)
+	}
+
+	if tmpConfig.channelTimezones, err = plugins.NewChannelTimezones(tmpConfig.ModuleConfig); err != nil {
+		return fmt.Errorf("loading channel timezones: %w", err)
+	}
+
 	configLock.Lock()
 	defer configLock.Unlock()
 
@@ -136,6 +158,8 @@ func loadConfig(filename string) error {
 
 	config = tmpConfig
 	timerService.UpdatePermitTimeout(tmpConfig.PermitTimeout)
+	updateRuleCrons(tmpConfig.Rules)
+	ruleExecService.UpdateRetention(tmpConfig.ExecutionHistory.MaxAge, tmpConfig.ExecutionHistory.MaxEntries)
 
 	logrus.WithFields(logrus.Fields{
 		"auto_messages": len(config.AutoMessages),
@@ -209,6 +233,27 @@ func patchConfig(filename, authorName, authorEmail, summary string, patcher func
 	return nil
 }
 
+// prioritizeRules orders the rules by descending priority keeping the
+// config order for equal priorities
+func prioritizeRules(rules []*plugins.Rule) []*plugins.Rule {
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+	return rules
+}
+
+// validateActionErrorHandling checks the retry policy and the
+// on_error actions of the given action
+func validateActionErrorHandling(a *plugins.RuleAction) error {
+	if a.Retry != nil && (a.Retry.Count < 1 || a.Retry.Delay < 0) {
+		return errors.New("retry needs a positive count and must not have a negative delay")
+	}
+
+	if err := validateActions(a.OnError); err != nil {
+		return fmt.Errorf("validating on_error actions: %w", err)
+	}
+
+	return nil
+}
+
 func writeConfigToYAML(filename, authorName, authorEmail, summary string, obj *configFile) error {
 	tmpFile, err := os.CreateTemp(path.Dir(filename), "twitch-bot-*.yaml")
 	if err != nil {
@@ -324,12 +369,12 @@ func (c configFile) GetMatchingRules(m *irc.Message, event *string, eventData *f
 	var out []*plugins.Rule
 
 	for _, r := range c.Rules {
-		if r.Matches(m, event, timerService, formatMessage, twitchClient, eventData) {
+		if r.Matches(m, event, timerService, formatMessage, twitchClient, c.channelTimezones, eventData) {
 			out = append(out, r)
 		}
 	}
 
-	return out
+	return prioritizeRules(out)
 }
 
 func (c configFile) LogRawMessage(m *irc.Message) error {
@@ -339,8 +384,35 @@ func (c configFile) LogRawMessage(m *irc.Message) error {
 	return nil
 }
 
+func (c *configFile) fixActionDurations(actions []*plugins.RuleAction) {
+	for _, ra := range actions {
+		if ra == nil {
+			continue
+		}
+
+		if ra.Retry != nil {
+			ra.Retry.Delay = c.fixedDuration(ra.Retry.Delay)
+		}
+
+		c.fixActionDurations(ra.OnError)
+	}
+}
+
+// compileRuleMatchers prepares the rules for matching before they are
+// made available to the message handlers
+func (c *configFile) compileRuleMatchers() error {
+	for _, r := range c.Rules {
+		if err := r.CompileMatchers(); err != nil {
+			return fmt.Errorf("rule %s: %w", r.MatcherID(), err)
+		}
+	}
+
+	return nil
+}
+
 func (c *configFile) fixDurations() {
 	// General fields
+	c.ExecutionHistory.MaxAge = c.fixedDuration(c.ExecutionHistory.MaxAge)
 	c.PermitTimeout = c.fixedDuration(c.PermitTimeout)
 
 	// Fix rules
@@ -348,6 +420,18 @@ func (c *configFile) fixDurations() {
 		r.ChannelCooldown = c.fixedDurationPtr(r.ChannelCooldown)
 		r.Cooldown = c.fixedDurationPtr(r.Cooldown)
 		r.UserCooldown = c.fixedDurationPtr(r.UserCooldown)
+
+		for _, l := range []*plugins.RuleUsageLimit{r.UsageLimit, r.ChannelUsageLimit, r.UserUsageLimit} {
+			if l != nil {
+				l.Window = c.fixedDuration(l.Window)
+			}
+		}
+
+		c.fixActionDurations(r.Actions)
+	}
+
+	for _, actions := range c.ActionSets {
+		c.fixActionDurations(actions)
 	}
 }
 
@@ -419,10 +503,18 @@ func (c *configFile) runLoadChecks() (err error) {
 		seen = append(seen, r.UUID)
 	}
 
+	if _, err = plugins.NewChannelTimezones(c.ModuleConfig); err != nil {
+		return fmt.Errorf("validating channel timezones: %w", err)
+	}
+
 	if err = c.validateRuleActions(); err != nil {
 		return fmt.Errorf("validating rule actions: %w", err)
 	}
 
+	if err = c.validateActionSets(); err != nil {
+		return fmt.Errorf("validating action sets: %w", err)
+	}
+
 	return nil
 }
 
@@ -481,6 +573,11 @@ func (c configFile) validateRuleActions() error {
 				logger.WithField("index", idx).WithError(err).Error("Actor reported invalid config")
 				hasError = true
 			}
+
+			if err = validateActionErrorHandling(a); err != nil {
+				logger.WithField("index", idx).WithError(err).Error("Action has invalid error handling")
+				hasError = true
+			}
 		}
 	}
 
@@ -490,3 +587,22 @@ func (c configFile) validateRuleActions() error {
 
 	return nil
 }
+
+func validateActions(actions []*plugins.RuleAction) error {
+	for idx, a := range actions {
+		actor, err := getActorByName(a.Type)
+		if err != nil {
+			return fmt.Errorf("getting actor for action %d: %w", idx, err)
+		}
+
+		if err = actor.Validate(validateTemplate, a.Attributes); err != nil {
+			return fmt.Errorf("validating action %d (%s): %w", idx, a.Type, err)
+		}
+
+		if err = validateActionErrorHandling(a); err != nil {
+			return fmt.Errorf("validating action %d (%s): %w", idx, a.Type, err)
+		}
+	}
+
+	return nil
+}
@@ -39,6 +39,7 @@ func registerActorDocumentation(doc plugins.ActionDocumentation) {
 }
 
 func init() {
+	registerEditorActionSetsRoutes()
 	registerEditorAutoMessageRoutes()
 	registerEditorFrontend()
 	registerEditorGeneralConfigRoutes()
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+func registerEditorActionSetsRoutes() {
+	for _, rd := range []plugins.HTTPRouteRegistrationArgs{
+		{
+			Description:         "Returns the current set of configured action sets in JSON format",
+			HandlerFunc:         configEditorActionSetsGet,
+			Method:              http.MethodGet,
+			Module:              moduleConfigEditor,
+			Name:                "Get current action sets",
+			Path:                "/action-sets",
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeJSON,
+		},
+		{
+			Description:         "Deletes the given action set",
+			HandlerFunc:         configEditorActionSetsDelete,
+			Method:              http.MethodDelete,
+			Module:              moduleConfigEditor,
+			Name:                "Delete action set",
+			Path:                "/action-sets/{name}",
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeTextPlain,
+			RouteParams: []plugins.HTTPRouteParamDocumentation{
+				{
+					Description: "Name of the action set to delete",
+					Name:        "name",
+					Required:    true,
+					Type:        "string",
+				},
+			},
+		},
+		{
+			Description:         "Creates or updates the given action set",
+			HandlerFunc:         configEditorActionSetsUpdate,
+			Method:              http.MethodPut,
+			Module:              moduleConfigEditor,
+			Name:                "Update action set",
+			Path:                "/action-sets/{name}",
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeTextPlain,
+			RouteParams: []plugins.HTTPRouteParamDocumentation{
+				{
+					Description: "Name of the action set to create or update",
+					Name:        "name",
+					Required:    true,
+					Type:        "string",
+				},
+			},
+		},
+	} {
+		if err := registerRoute(rd); err != nil {
+			log.WithError(err).Fatal("Unable to register config editor route")
+		}
+	}
+}
+
+func configEditorActionSetsDelete(w http.ResponseWriter, r *http.Request) {
+	user, _, err := getAuthorizedUserFromRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Errorf("getting authorized user: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := patchConfig(cfg.Config, user, "", "Delete action set", func(c *configFile) error {
+		delete(c.ActionSets, mux.Vars(r)["name"])
+		return nil
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func configEditorActionSetsGet(w http.ResponseWriter, _ *http.Request) {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	sets := config.ActionSets
+	if sets == nil {
+		sets = map[string][]*plugins.RuleAction{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sets); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func configEditorActionSetsUpdate(w http.ResponseWriter, r *http.Request) {
+	user, _, err := getAuthorizedUserFromRequest(r)
+	if err != nil {
+		http.Error(w, fmt.Errorf("getting authorized user: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var actions []*plugins.RuleAction
+	if err := json.NewDecoder(r.Body).Decode(&actions); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := patchConfig(cfg.Config, user, "", "Update action set", func(c *configFile) error {
+		if c.ActionSets == nil {
+			c.ActionSets = make(map[string][]*plugins.RuleAction)
+		}
+
+		c.ActionSets[mux.Vars(r)["name"]] = actions
+		return nil
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
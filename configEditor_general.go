@@ -11,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
 	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
@@ -64,6 +65,16 @@ func registerEditorGeneralConfigRoutes() {
 			RequiresEditorsAuth: true,
 			ResponseType:        plugins.HTTPRouteResponseTypeJSON,
 		},
+		{
+			Description:         "Returns the state of the EventSub topics per channel",
+			HandlerFunc:         configEditorHandleGeneralEventSubStatus,
+			Method:              http.MethodGet,
+			Module:              moduleConfigEditor,
+			Name:                "Get EventSub status",
+			Path:                "/eventsub-status",
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeJSON,
+		},
 		{
 			Description:         "Returns the current general config",
 			HandlerFunc:         configEditorHandleGeneralGet,
@@ -188,6 +199,18 @@ func configEditorHandleGeneralDeleteAuthToken(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func configEditorHandleGeneralEventSubStatus(w http.ResponseWriter, _ *http.Request) {
+	status := map[string][]twitch.EventSubTopicStatus{}
+	if twitchWatch != nil {
+		status = twitchWatch.EventSubStatus()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func configEditorHandleGeneralGet(w http.ResponseWriter, _ *http.Request) {
 	resp := configEditorGeneralConfig{
 		BotEditors:      config.BotEditors,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gofrs/uuid/v3"
 	"github.com/gorilla/mux"
@@ -13,6 +14,8 @@ import (
 	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
+const defaultRuleExecutionsLimit = 50
+
 func registerEditorRulesRoutes() {
 	for _, rd := range []plugins.HTTPRouteRegistrationArgs{
 		{
@@ -35,6 +38,16 @@ func registerEditorRulesRoutes() {
 			RequiresEditorsAuth: true,
 			ResponseType:        plugins.HTTPRouteResponseTypeTextPlain,
 		},
+		{
+			Description:         "Evaluates all rules against the given synthetic message or event without executing any actions and reports the verdict of each matcher",
+			HandlerFunc:         configEditorRulesDryRun,
+			Method:              http.MethodPost,
+			Module:              moduleConfigEditor,
+			Name:                "Dry-run rules",
+			Path:                "/rules/dry-run",
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeJSON,
+		},
 		{
 			Description:         "Deletes the given Rule",
 			HandlerFunc:         configEditorRulesDelete,
@@ -53,6 +66,32 @@ func registerEditorRulesRoutes() {
 				},
 			},
 		},
+		{
+			Description: "Returns the latest recorded executions of the given Rule including the outcome of each action",
+			HandlerFunc: configEditorRulesGetExecutions,
+			Method:      http.MethodGet,
+			Module:      moduleConfigEditor,
+			Name:        "Get Rule executions",
+			Path:        "/rules/{uuid}/executions",
+			QueryParams: []plugins.HTTPRouteParamDocumentation{
+				{
+					Description: "Maximum number of executions to return (default 50)",
+					Name:        "limit",
+					Required:    false,
+					Type:        "int64",
+				},
+			},
+			RequiresEditorsAuth: true,
+			ResponseType:        plugins.HTTPRouteResponseTypeJSON,
+			RouteParams: []plugins.HTTPRouteParamDocumentation{
+				{
+					Description: "UUID of the rule to fetch the executions for",
+					Name:        "uuid",
+					Required:    true,
+					Type:        "string",
+				},
+			},
+		},
 		{
 			Description:         "Updates the given Rule",
 			HandlerFunc:         configEditorRulesUpdate,
@@ -148,6 +187,25 @@ func configEditorRulesDelete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func configEditorRulesDryRun(w http.ResponseWriter, r *http.Request) {
+	var req ruleDryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	res, err := dryRunRules(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func configEditorRulesGet(w http.ResponseWriter, _ *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(config.Rules); err != nil {
@@ -155,6 +213,28 @@ func configEditorRulesGet(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+func configEditorRulesGetExecutions(w http.ResponseWriter, r *http.Request) {
+	limit := defaultRuleExecutionsLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	execs, err := ruleExecService.ListExecutions(mux.Vars(r)["uuid"], limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(execs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func configEditorRulesUpdate(w http.ResponseWriter, r *http.Request) {
 	user, _, err := getAuthorizedUserFromRequest(r)
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
 func TestAuthTokenValidate(t *testing.T) {
@@ -33,3 +35,20 @@ func TestAuthTokenValidate(t *testing.T) {
 		"invalid bcrypt token",
 	)
 }
+
+func TestPrioritizeRules(t *testing.T) {
+	var (
+		linkProtect = &plugins.Rule{UUID: "link-protect", Group: "links"}
+		linkPermit  = &plugins.Rule{UUID: "link-permit", Group: "links", Priority: 10}
+		greeting    = &plugins.Rule{UUID: "greeting"}
+		shoutout    = &plugins.Rule{UUID: "shoutout", Priority: 5}
+		spamA       = &plugins.Rule{UUID: "spam-a", Group: "spam", Priority: 1}
+		spamB       = &plugins.Rule{UUID: "spam-b", Group: "spam", Priority: 1}
+	)
+
+	require.Equal(
+		t,
+		[]*plugins.Rule{linkPermit, shoutout, spamA, spamB, linkProtect, greeting},
+		prioritizeRules([]*plugins.Rule{linkProtect, greeting, spamA, linkPermit, shoutout, spamB}),
+	)
+}
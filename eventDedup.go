@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const eventDedupWindow = time.Minute
+
+const (
+	eventSourceIRC eventSource = iota
+	eventSourceEventSub
+)
+
+type (
+	eventSource uint8
+
+	// eventDeduplicator matches events received through IRC and
+	// EventSub: every event seen through one source cancels out one
+	// event with the same key seen through the other source within
+	// the window. This way each event is emitted once through the
+	// source delivering it first.
+	eventDeduplicator struct {
+		entries map[string]*eventDedupEntry
+		lock    sync.Mutex
+		window  time.Duration
+	}
+
+	eventDedupEntry struct {
+		pending [2]int
+		expires time.Time
+	}
+)
+
+var eventDedup = newEventDeduplicator(eventDedupWindow)
+
+func newEventDeduplicator(window time.Duration) *eventDeduplicator {
+	return &eventDeduplicator{
+		entries: make(map[string]*eventDedupEntry),
+		window:  window,
+	}
+}
+
+// ShouldEmit registers the event as seen through the given source
+// and reports whether it should be emitted or is a duplicate of an
+// event already emitted through the other source
+func (e *eventDeduplicator) ShouldEmit(src eventSource, event string, keyParts ...string) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	now := time.Now()
+	for k, entry := range e.entries {
+		if entry.expires.Before(now) {
+			delete(e.entries, k)
+		}
+	}
+
+	key := strings.ToLower(strings.Join(append([]string{event}, keyParts...), "|"))
+	entry, ok := e.entries[key]
+	if !ok {
+		entry = &eventDedupEntry{}
+		e.entries[key] = entry
+	}
+
+	other := eventSourceEventSub
+	if src == eventSourceEventSub {
+		other = eventSourceIRC
+	}
+
+	if entry.pending[other] > 0 {
+		// Already emitted through the other source
+		entry.pending[other]--
+		return false
+	}
+
+	entry.pending[src]++
+	entry.expires = now.Add(e.window)
+	return true
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventDeduplicator(t *testing.T) {
+	d := newEventDeduplicator(time.Minute)
+
+	// IRC first, EventSub duplicate is dropped
+	assert.True(t, d.ShouldEmit(eventSourceIRC, "sub", "#mychannel", "amy"))
+	assert.False(t, d.ShouldEmit(eventSourceEventSub, "sub", "#mychannel", "Amy"))
+
+	// EventSub first, IRC duplicate is dropped
+	assert.True(t, d.ShouldEmit(eventSourceEventSub, "sub", "#mychannel", "amy"))
+	assert.False(t, d.ShouldEmit(eventSourceIRC, "sub", "#mychannel", "amy"))
+
+	// Multiple events from one source are all emitted and cancel out
+	// the same number of events from the other source
+	assert.True(t, d.ShouldEmit(eventSourceIRC, "bits", "#mychannel", "amy", "100"))
+	assert.True(t, d.ShouldEmit(eventSourceIRC, "bits", "#mychannel", "amy", "100"))
+	assert.False(t, d.ShouldEmit(eventSourceEventSub, "bits", "#mychannel", "amy", "100"))
+	assert.False(t, d.ShouldEmit(eventSourceEventSub, "bits", "#mychannel", "amy", "100"))
+	assert.True(t, d.ShouldEmit(eventSourceEventSub, "bits", "#mychannel", "amy", "100"))
+
+	// Different keys do not interfere
+	assert.True(t, d.ShouldEmit(eventSourceIRC, "resub", "#mychannel", "bob"))
+	assert.True(t, d.ShouldEmit(eventSourceEventSub, "resub", "#mychannel", "amy"))
+}
+
+func TestEventDeduplicatorExpiry(t *testing.T) {
+	d := newEventDeduplicator(time.Millisecond)
+
+	assert.True(t, d.ShouldEmit(eventSourceIRC, "sub", "#mychannel", "amy"))
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, d.ShouldEmit(eventSourceEventSub, "sub", "#mychannel", "amy"))
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+func init() {
+	if err := registerRoute(plugins.HTTPRouteRegistrationArgs{
+		Description: "Injects a synthetic event of any known type into the rule processing, overlays and event handlers just like a real event",
+		HandlerFunc: handleEventInjection,
+		Method:      http.MethodPost,
+		Module:      "events",
+		Name:        "Inject event",
+		Path:        "/{channel}/{event}",
+		QueryParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Mark the event as test event by setting the `is_test` field",
+				Name:        "is_test",
+				Required:    false,
+				Type:        "bool",
+			},
+		},
+		RequiresWriteAuth: true,
+		ResponseType:      plugins.HTTPRouteResponseTypeNo200,
+		RouteParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Channel to create the event in",
+				Name:        "channel",
+			},
+			{
+				Description: "Type of the event to create (see documentation for available events)",
+				Name:        "event",
+			},
+		},
+	}); err != nil {
+		logrus.WithError(err).Fatal("registering event injection route")
+	}
+}
+
+func handleEventInjection(w http.ResponseWriter, r *http.Request) {
+	var (
+		channel = strings.ToLower(strings.TrimLeft(mux.Vars(r)["channel"], "#"))
+		event   = getInjectableEvent(mux.Vars(r)["event"])
+	)
+
+	if channel == "" {
+		http.Error(w, "missing channel", http.StatusBadRequest)
+		return
+	}
+
+	if !isConfiguredChannel(channel) {
+		http.Error(w, fmt.Sprintf("channel %q is not configured", channel), http.StatusBadRequest)
+		return
+	}
+
+	if event == nil {
+		http.Error(w, fmt.Sprintf("unknown event %q", mux.Vars(r)["event"]), http.StatusBadRequest)
+		return
+	}
+
+	fields, err := parseInjectedEventFields(channel, r.Body) //#nosec:G120 // Request body size is limited by API route registration middleware
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if v := r.FormValue("is_test"); v != "" {
+		isTest, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, fmt.Errorf("parsing is_test: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+		fields.Set("is_test", isTest)
+	}
+
+	if ircHdl == nil {
+		http.Error(w, "bot is not connected to chat", http.StatusServiceUnavailable)
+		return
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"channel": "#" + channel,
+		"event":   *event,
+	}).Info("Injecting synthetic event")
+
+	go handleMessage(ircHdl.Client(), nil, event, fields)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getInjectableEvent returns the pointer to the known event with the
+// given name or nil if there is no such event. The cron event is only
+// created internally for scheduled rules and cannot be injected.
+func getInjectableEvent(name string) *string {
+	for _, evt := range knownEvents {
+		if *evt == name && evt != eventTypeCron {
+			return evt
+		}
+	}
+
+	return nil
+}
+
+// isConfiguredChannel checks whether the channel (without leading #)
+// is one of the channels the bot is configured for
+func isConfiguredChannel(channel string) bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	return slices.ContainsFunc(config.Channels, func(c string) bool {
+		return strings.EqualFold(strings.TrimLeft(c, "#"), channel)
+	})
+}
+
+// parseInjectedEventFields reads the JSON object from the body into a
+// FieldCollection and enforces the channel field to match the given
+// channel
+func parseInjectedEventFields(channel string, body io.Reader) (*fieldcollection.FieldCollection, error) {
+	payload := make(map[string]any)
+
+	if err := json.NewDecoder(body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing event payload: %w", err)
+	}
+
+	fields := fieldcollection.FromData(payload)
+	fields.Set("channel", "#"+channel)
+
+	return fields, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleEventInjection(t *testing.T) {
+	type injectedEvent struct {
+		event  string
+		fields *fieldcollection.FieldCollection
+	}
+
+	injected := make(chan injectedEvent, 1)
+	require.NoError(t, registerEventHandlers(func(event string, eventData *fieldcollection.FieldCollection) error {
+		if eventData.MustString("channel", nil) != "#injectchannel" {
+			return nil
+		}
+
+		select {
+		case injected <- injectedEvent{event, eventData}:
+		default:
+			// Must not block other handlers when nobody is listening
+		}
+		return nil
+	}))
+
+	configLock.Lock()
+	prevConfig, prevIRC := config, ircHdl
+	config = newConfigFile()
+	config.Channels = []string{"injectchannel"}
+	ircHdl = &ircHandler{}
+	configLock.Unlock()
+
+	t.Cleanup(func() {
+		configLock.Lock()
+		config, ircHdl = prevConfig, prevIRC
+		configLock.Unlock()
+	})
+
+	inject := func(channel, event, query, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/events/"+channel+"/"+event+query, strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"channel": channel, "event": event})
+
+		resp := httptest.NewRecorder()
+		handleEventInjection(resp, req)
+		return resp
+	}
+
+	for name, tc := range map[string]struct {
+		channel, event, query, body string
+		expStatus                   int
+	}{
+		"unknown event":   {"injectchannel", "notanevent", "", "", http.StatusBadRequest},
+		"cron event":      {"injectchannel", "cron", "", "", http.StatusBadRequest},
+		"foreign channel": {"otherchannel", "follow", "", "", http.StatusBadRequest},
+		"invalid body":    {"injectchannel", "follow", "", "{", http.StatusBadRequest},
+		"invalid is_test": {"injectchannel", "follow", "?is_test=maybe", "", http.StatusBadRequest},
+	} {
+		assert.Equal(t, tc.expStatus, inject(tc.channel, tc.event, tc.query, tc.body).Code, name)
+	}
+
+	select {
+	case evt := <-injected:
+		t.Fatalf("rejected request caused %q event", evt.event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Valid event is dispatched with enforced channel and test flag
+	resp := inject("InjectChannel", "follow", "?is_test=true", `{"channel":"#otherchannel","user":"amy"}`)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	select {
+	case evt := <-injected:
+		assert.Equal(t, "follow", evt.event)
+		assert.Equal(t, "amy", evt.fields.MustString("user", nil))
+		assert.True(t, evt.fields.MustBool("is_test", nil))
+	case <-time.After(time.Second):
+		t.Fatal("injected event was not dispatched")
+	}
+}
@@ -17,11 +17,14 @@ var (
 var (
 	eventTypeAdBreakBegin       = new("adbreak_begin")
 	eventTypeAnnouncement       = new("announcement")
+	eventTypeAutomodHold        = new("automod_hold")
+	eventTypeAutomodUpdate      = new("automod_update")
 	eventTypeBan                = new("ban")
 	eventTypeBits               = new("bits")
 	eventTypeCustom             = new("custom")
 	eventTypeChannelPointRedeem = new("channelpoint_redeem")
 	eventTypeClearChat          = new("clearchat")
+	eventTypeCron               = new(plugins.EventTypeCron)
 	eventTypeDelete             = new("delete")
 	eventTypeFollow             = new("follow")
 	eventTypeGiftPaidUpgrade    = new("giftpaidupgrade")
@@ -30,12 +33,17 @@ var (
 	eventTypeHypetrainProgress  = new("hypetrain_progress")
 	eventTypeJoin               = new("join")
 	eventKoFiDonation           = new("kofi_donation")
+	eventTypeModAction          = new("mod_action")
 	eventTypeOutboundRaid       = new("outbound_raid")
 	eventTypePart               = new("part")
 	eventTypePermit             = new("permit")
 	eventTypePollBegin          = new("poll_begin")
 	eventTypePollEnd            = new("poll_end")
 	eventTypePollProgress       = new("poll_progress")
+	eventTypePredictionBegin    = new("prediction_begin")
+	eventTypePredictionEnd      = new("prediction_end")
+	eventTypePredictionLock     = new("prediction_lock")
+	eventTypePredictionProgress = new("prediction_progress")
 	eventTypeRaid               = new("raid")
 	eventTypeResub              = new("resub")
 	eventTypeShoutoutCreated    = new("shoutout_created")
@@ -46,6 +54,10 @@ var (
 	eventTypeSusUserMessage     = new("sus_user_message")
 	eventTypeSusUserUpdate      = new("sus_user_update")
 	eventTypeTimeout            = new("timeout")
+	eventTypeUnbanRequest       = new("unban_request")
+	eventTypeUnbanResolved      = new("unban_request_resolved")
+	eventTypeWarn               = new("warn")
+	eventTypeWarnAcknowledge    = new("warn_acknowledge")
 	eventTypeWatchStreak        = new("watch_streak")
 	eventTypeWhisper            = new("whisper")
 
@@ -57,11 +69,14 @@ var (
 	knownEvents = []*string{
 		eventTypeAdBreakBegin,
 		eventTypeAnnouncement,
+		eventTypeAutomodHold,
+		eventTypeAutomodUpdate,
 		eventTypeBan,
 		eventTypeBits,
 		eventTypeCustom,
 		eventTypeChannelPointRedeem,
 		eventTypeClearChat,
+		eventTypeCron,
 		eventTypeDelete,
 		eventTypeFollow,
 		eventTypeGiftPaidUpgrade,
@@ -70,12 +85,17 @@ var (
 		eventTypeHypetrainProgress,
 		eventTypeJoin,
 		eventKoFiDonation,
+		eventTypeModAction,
 		eventTypeOutboundRaid,
 		eventTypePart,
 		eventTypePermit,
 		eventTypePollBegin,
 		eventTypePollEnd,
 		eventTypePollProgress,
+		eventTypePredictionBegin,
+		eventTypePredictionEnd,
+		eventTypePredictionLock,
+		eventTypePredictionProgress,
 		eventTypeRaid,
 		eventTypeResub,
 		eventTypeShoutoutCreated,
@@ -86,6 +106,10 @@ var (
 		eventTypeSusUserMessage,
 		eventTypeSusUserUpdate,
 		eventTypeTimeout,
+		eventTypeUnbanRequest,
+		eventTypeUnbanResolved,
+		eventTypeWarn,
+		eventTypeWarnAcknowledge,
 		eventTypeWatchStreak,
 		eventTypeWhisper,
 
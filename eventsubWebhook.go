@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+)
+
+const (
+	eventSubTransportConduit   = "conduit"
+	eventSubTransportWebhook   = "webhook"
+	eventSubTransportWebsocket = "websocket"
+
+	eventSubWebhookPath = "/eventsub/webhook"
+)
+
+// initEventSubWebhook creates the webhook handler and mounts it into
+// the router in case the webhook or conduit transport is selected.
+// For the websocket transport nil is returned.
+func initEventSubWebhook() (*twitch.EventSubWebhookHandler, error) {
+	var opts []twitch.EventSubWebhookHandlerOpt
+
+	switch cfg.EventSubTransport {
+	case eventSubTransportWebsocket:
+		return nil, nil //nolint:nilnil // No handler is needed for websockets
+
+	case eventSubTransportConduit:
+		opts = append(opts, twitch.WithWebhookConduit(cfg.EventSubConduitID, cfg.EventSubConduitShards, cfg.EventSubConduitShard))
+
+	case eventSubTransportWebhook:
+		// No extra options
+
+	default:
+		return nil, fmt.Errorf("unknown eventsub-transport %q", cfg.EventSubTransport)
+	}
+
+	if config.HTTPListen == "" {
+		return nil, errors.New("eventsub webhook transport requires http_listen to be set")
+	}
+
+	callbackURL := cfg.EventSubWebhookURL
+	if callbackURL == "" {
+		if cfg.BaseURL == "" {
+			return nil, errors.New("eventsub webhook transport requires eventsub-webhook-url or base-url to be set")
+		}
+		callbackURL = strings.TrimRight(cfg.BaseURL, "/") + eventSubWebhookPath
+	}
+
+	// The handler always uses the app-access-token so it must not be
+	// bound to the bot-user token of the twitchClient
+	h, err := twitch.NewEventSubWebhookHandler(
+		twitch.New(cfg.TwitchClient, cfg.TwitchClientSecret, "", ""),
+		callbackURL,
+		cfg.EventSubWebhookSecret,
+		append(opts, twitch.WithWebhookHandlerLogger(log.WithField("transport", cfg.EventSubTransport)))...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating webhook handler: %w", err)
+	}
+
+	router.Handle(eventSubWebhookPath, h)
+
+	return h, nil
+}
@@ -0,0 +1,114 @@
+// Package automod contains an actor to approve or deny messages held
+// by AutoMod for review
+package automod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actionApprove = "approve"
+	actionDeny    = "deny"
+
+	actorName = "automod"
+)
+
+type actor struct{}
+
+var (
+	botTwitchClient func() *twitch.Client
+	formatMessage   plugins.MsgFormatter
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	botTwitchClient = args.GetTwitchClient
+	formatMessage = args.FormatMessage
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Approve or deny a message held by AutoMod (requires the bot to be moderator with moderator:manage:automod scope)",
+		Name:        "Manage AutoMod Message",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Action to execute (one of: approve, deny)",
+				Key:             "action",
+				Name:            "Action",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "ID of the held message (defaults to the `message_id` of the `automod_hold` event)",
+				Key:             "message_id",
+				Name:            "Message ID",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	msgID, err := formatMessage(attrs.MustString("message_id", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing message_id template: %w", err)
+	}
+
+	if msgID == "" {
+		msgID = eventData.MustString("message_id", new(""))
+	}
+
+	if msgID == "" {
+		return false, errors.New("no message_id available")
+	}
+
+	if err = botTwitchClient().ManageHeldAutomodMessage(
+		context.Background(),
+		msgID,
+		attrs.MustString("action", new("")) == actionApprove,
+	); err != nil {
+		return false, fmt.Errorf("managing held message: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "action", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "message_id", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "message_id"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	switch action := attrs.MustString("action", new("")); action {
+	case actionApprove, actionDeny:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
@@ -0,0 +1,116 @@
+package automod
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, the bot has the ID 123 and AutoMod requests are
+// answered by the given handler
+func setupTestActor(t *testing.T, automod http.HandlerFunc) {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"bot"}]}`)
+
+		default:
+			automod(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	botTwitchClient = func() *twitch.Client { return tc }
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+}
+
+func TestExecute(t *testing.T) {
+	for name, tc := range map[string]struct {
+		attrs     map[string]any
+		eventData map[string]any
+		expErr    bool
+		expBody   map[string]string
+	}{
+		"approve from event": {
+			attrs:     map[string]any{"action": "approve"},
+			eventData: map[string]any{"message_id": "evtmsg"},
+			expBody:   map[string]string{"action": "ALLOW", "msg_id": "evtmsg", "user_id": "123"},
+		},
+		"deny given message": {
+			attrs:     map[string]any{"action": "deny", "message_id": "msg"},
+			eventData: map[string]any{"message_id": "evtmsg"},
+			expBody:   map[string]string{"action": "DENY", "msg_id": "msg", "user_id": "123"},
+		},
+		"no message": {
+			attrs:  map[string]any{"action": "deny"},
+			expErr: true,
+		},
+	} {
+		var reqBody map[string]string
+		setupTestActor(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/helix/moderation/automod/message", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		_, err := actor{}.Execute(nil, nil, nil, fieldcollection.FromData(tc.eventData), fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+			assert.Nil(t, reqBody, name)
+			continue
+		}
+
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expBody, reqBody, name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"approve":        {map[string]any{"action": "approve"}, false},
+		"deny":           {map[string]any{"action": "deny", "message_id": "{{ .message_id }}"}, false},
+		"unknown action": {map[string]any{"action": "ignore"}, true},
+		"no action":      {map[string]any{"message_id": "msg"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Package blockedterms contains an actor and API routes to manage the
+// blocked terms of a channel
+package blockedterms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/irc.v4"
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actionAdd    = "add"
+	actionRemove = "remove"
+
+	actorName = "blockedterm"
+)
+
+type actor struct{}
+
+var (
+	botTwitchClient func() *twitch.Client
+	db              database.Connector
+	formatMessage   plugins.MsgFormatter
+	logger          *logrus.Entry
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) (err error) {
+	db = args.GetDatabaseConnector()
+	if err = db.DB().AutoMigrate(&blockedTermExpiry{}); err != nil {
+		return fmt.Errorf("applying schema migration: %w", err)
+	}
+
+	args.RegisterCopyDatabaseFunc("blockedterms", func(src, target *gorm.DB) error {
+		return database.CopyObjects(src, target, &blockedTermExpiry{})
+	})
+
+	botTwitchClient = args.GetTwitchClient
+	formatMessage = args.FormatMessage
+	logger = args.GetLogger("blockedterms")
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Add or remove a blocked term in the channel (requires the bot to be moderator with moderator:manage:blocked_terms scope)",
+		Name:        "Manage Blocked Term",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Action to execute (one of: add, remove)",
+				Key:             "action",
+				Name:            "Action",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "0s",
+				Description:     "When adding a term remove it again after this duration (0s = keep forever, permanently blocked terms are kept)",
+				Key:             "expires",
+				Name:            "Expires",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+			{
+				Default:         "",
+				Description:     "Term to add or remove",
+				Key:             "term",
+				Name:            "Term",
+				Optional:        false,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	if err = registerAPI(args.RegisterAPIRoute); err != nil {
+		return fmt.Errorf("registering API: %w", err)
+	}
+
+	if _, err = args.RegisterCron("@every 1m", removeExpiredTerms); err != nil {
+		return fmt.Errorf("registering expiry cron: %w", err)
+	}
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	channel := plugins.DeriveChannel(m, eventData)
+
+	term, err := formatMessage(attrs.MustString("term", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing term template: %w", err)
+	}
+
+	if term = strings.TrimSpace(term); term == "" {
+		return false, errors.New("term resolved to empty string")
+	}
+
+	switch attrs.MustString("action", new("")) {
+	case actionAdd:
+		if err = addTerm(context.Background(), channel, term, attrs.MustDuration("expires", new(time.Duration(0)))); err != nil {
+			return false, fmt.Errorf("adding term: %w", err)
+		}
+
+	case actionRemove:
+		if err = removeTerm(context.Background(), channel, term); err != nil {
+			return false, fmt.Errorf("removing term: %w", err)
+		}
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "action", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "term", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "expires", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "term"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	switch action := attrs.MustString("action", new("")); action {
+	case actionAdd, actionRemove:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
+
+// addTerm adds the term to the channel and tracks its expiry if given.
+// Adding an already blocked term without expiry makes it permanent,
+// adding an already permanent term with expiry keeps it permanent.
+func addTerm(ctx context.Context, channel, term string, expires time.Duration) error {
+	terms, err := botTwitchClient().GetBlockedTerms(ctx, channel)
+	if err != nil {
+		return fmt.Errorf("getting blocked terms: %w", err)
+	}
+
+	var existed bool
+	for _, bt := range terms {
+		existed = existed || strings.EqualFold(bt.Text, term)
+	}
+
+	bt, err := botTwitchClient().AddBlockedTerm(ctx, channel, term)
+	if err != nil {
+		return fmt.Errorf("adding blocked term: %w", err)
+	}
+
+	if expires <= 0 {
+		return deleteExpiry(db, bt.ID)
+	}
+
+	if existed {
+		hasExp, err := hasExpiry(db, bt.ID)
+		if err != nil {
+			return err
+		}
+
+		if !hasExp {
+			// Term was blocked permanently before, don't let it expire
+			return nil
+		}
+	}
+
+	return setExpiry(db, channel, bt.ID, bt.Text, time.Now().Add(expires))
+}
+
+// removeTerm removes the term from the channel if it is blocked
+func removeTerm(ctx context.Context, channel, term string) error {
+	terms, err := botTwitchClient().GetBlockedTerms(ctx, channel)
+	if err != nil {
+		return fmt.Errorf("getting blocked terms: %w", err)
+	}
+
+	for _, bt := range terms {
+		if !strings.EqualFold(bt.Text, term) {
+			continue
+		}
+
+		if err = botTwitchClient().RemoveBlockedTerm(ctx, channel, bt.ID); err != nil {
+			return fmt.Errorf("removing blocked term: %w", err)
+		}
+
+		if err = deleteExpiry(db, bt.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func removeExpiredTerms() {
+	terms, err := getExpiredTerms(db, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("fetching expired terms")
+		return
+	}
+
+	for _, t := range terms {
+		if err = botTwitchClient().RemoveBlockedTerm(context.Background(), t.Channel, t.TermID); err != nil {
+			logger.WithError(err).WithField("channel", t.Channel).Error("removing expired term")
+			continue
+		}
+
+		if err = deleteExpiry(db, t.TermID); err != nil {
+			logger.WithError(err).WithField("channel", t.Channel).Error("removing expiry of removed term")
+		}
+	}
+}
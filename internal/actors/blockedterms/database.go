@@ -0,0 +1,80 @@
+package blockedterms
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+type (
+	// blockedTermExpiry tracks terms added with an expiry as Twitch
+	// does not support expiring terms through the API
+	blockedTermExpiry struct {
+		TermID    string `gorm:"primaryKey"`
+		Channel   string `gorm:"not null"`
+		Text      string
+		ExpiresAt time.Time `gorm:"index"`
+	}
+)
+
+func deleteExpiry(db database.Connector, termID string) error {
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		return tx.Delete(&blockedTermExpiry{}, "term_id = ?", termID).Error
+	}); err != nil {
+		return fmt.Errorf("deleting term expiry: %w", err)
+	}
+
+	return nil
+}
+
+func getExpiredTerms(db database.Connector, at time.Time) (terms []blockedTermExpiry, err error) {
+	if err = helpers.Retry(func() error {
+		return db.DB().
+			Where("expires_at <= ?", at.UTC()).
+			Order("expires_at").
+			Find(&terms).
+			Error
+	}); err != nil {
+		return nil, fmt.Errorf("querying expired terms: %w", err)
+	}
+
+	return terms, nil
+}
+
+func hasExpiry(db database.Connector, termID string) (has bool, err error) {
+	var count int64
+	if err = helpers.Retry(func() error {
+		return db.DB().
+			Model(&blockedTermExpiry{}).
+			Where("term_id = ?", termID).
+			Count(&count).
+			Error
+	}); err != nil {
+		return false, fmt.Errorf("counting term expiries: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func setExpiry(db database.Connector, channel, termID, text string, expiresAt time.Time) error {
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "term_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"channel", "text", "expires_at"}),
+		}).Create(&blockedTermExpiry{
+			TermID:    termID,
+			Channel:   channel,
+			Text:      text,
+			ExpiresAt: expiresAt.UTC(),
+		}).Error
+	}); err != nil {
+		return fmt.Errorf("storing term expiry: %w", err)
+	}
+
+	return nil
+}
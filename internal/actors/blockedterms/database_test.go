@@ -0,0 +1,54 @@
+package blockedterms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+func TestExpiryRoundtrip(t *testing.T) {
+	dbc := database.GetTestDatabase(t)
+	require.NoError(t, dbc.DB().AutoMigrate(&blockedTermExpiry{}))
+
+	now := time.Now()
+
+	require.NoError(t, setExpiry(dbc, "#test", "1", "foo", now.Add(-time.Minute)))
+	require.NoError(t, setExpiry(dbc, "#test", "2", "bar", now.Add(time.Hour)))
+
+	terms, err := getExpiredTerms(dbc, now)
+	require.NoError(t, err)
+	require.Len(t, terms, 1)
+	assert.Equal(t, "1", terms[0].TermID)
+	assert.Equal(t, "#test", terms[0].Channel)
+	assert.Equal(t, "foo", terms[0].Text)
+
+	has, err := hasExpiry(dbc, "1")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	has, err = hasExpiry(dbc, "3")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Updating the expiry of an existing term must not duplicate it
+	require.NoError(t, setExpiry(dbc, "#test", "2", "bar", now.Add(-time.Second)))
+
+	terms, err = getExpiredTerms(dbc, now)
+	require.NoError(t, err)
+	assert.Len(t, terms, 2)
+
+	require.NoError(t, deleteExpiry(dbc, "1"))
+	require.NoError(t, deleteExpiry(dbc, "2"))
+
+	terms, err = getExpiredTerms(dbc, now)
+	require.NoError(t, err)
+	assert.Empty(t, terms)
+
+	has, err = hasExpiry(dbc, "1")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
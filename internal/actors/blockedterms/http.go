@@ -0,0 +1,227 @@
+package blockedterms
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type (
+	syncResult struct {
+		Added   []string `json:"added"`
+		Removed []string `json:"removed"`
+	}
+)
+
+func registerAPI(register plugins.HTTPRouteRegistrationFunc) (err error) {
+	if err = register(plugins.HTTPRouteRegistrationArgs{
+		Description:       "Lists the blocked terms of the {channel}, one term per line (same format as accepted by the sync)",
+		HandlerFunc:       handleListTerms,
+		Method:            http.MethodGet,
+		Module:            "blockedterms",
+		Name:              "List Blocked Terms",
+		Path:              "/{channel}",
+		RequiresWriteAuth: true, // Blocked terms might contain stuff not to be shown publicly
+		ResponseType:      plugins.HTTPRouteResponseTypeTextPlain,
+		RouteParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Channel to list the blocked terms for",
+				Name:        "channel",
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("registering API route: %w", err)
+	}
+
+	if err = register(plugins.HTTPRouteRegistrationArgs{
+		Description: "Synchronizes the blocked terms of the {channel} with the list given in the body (one term per line, empty lines and lines starting with # are ignored): missing terms are added, terms not in the list are removed",
+		HandlerFunc: handleSyncTerms,
+		Method:      http.MethodPut,
+		Module:      "blockedterms",
+		Name:        "Sync Blocked Terms",
+		Path:        "/{channel}",
+		QueryParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Accept an empty list (removes all terms unless keep_unlisted is set)",
+				Name:        "allow_empty",
+				Required:    false,
+				Type:        "bool",
+			},
+			{
+				Description: "Do not remove terms not contained in the list",
+				Name:        "keep_unlisted",
+				Required:    false,
+				Type:        "bool",
+			},
+		},
+		RequiresWriteAuth: true,
+		ResponseType:      plugins.HTTPRouteResponseTypeJSON,
+		RouteParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Channel to sync the blocked terms for",
+				Name:        "channel",
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("registering API route: %w", err)
+	}
+
+	return nil
+}
+
+func handleListTerms(w http.ResponseWriter, r *http.Request) {
+	channel := "#" + strings.TrimLeft(mux.Vars(r)["channel"], "#")
+
+	terms, err := botTwitchClient().GetBlockedTerms(r.Context(), channel)
+	if err != nil {
+		http.Error(w, fmt.Errorf("getting blocked terms: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	for _, bt := range terms {
+		fmt.Fprintln(w, bt.Text) //nolint:errcheck // Nothing to do about errors writing the response
+	}
+}
+
+func handleSyncTerms(w http.ResponseWriter, r *http.Request) {
+	var (
+		allowEmpty   bool
+		channel      = "#" + strings.TrimLeft(mux.Vars(r)["channel"], "#")
+		keepUnlisted bool
+		err          error
+	)
+
+	for param, target := range map[string]*bool{
+		"allow_empty":   &allowEmpty,
+		"keep_unlisted": &keepUnlisted,
+	} {
+		if v := r.FormValue(param); v != "" {
+			if *target, err = strconv.ParseBool(v); err != nil {
+				http.Error(w, fmt.Errorf("parsing %s: %w", param, err).Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	wanted, err := parseTermList(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Errorf("parsing term list: %w", err).Error(), http.StatusBadRequest)
+		return
+	}
+
+	if len(wanted) == 0 && !allowEmpty && !keepUnlisted {
+		// Most likely a broken file, do not wipe all terms of the channel
+		http.Error(w, "term list is empty, pass allow_empty=true to remove all terms", http.StatusBadRequest)
+		return
+	}
+
+	current, err := botTwitchClient().GetBlockedTerms(r.Context(), channel)
+	if err != nil {
+		http.Error(w, fmt.Errorf("getting blocked terms: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	add, keep, remove := diffTerms(current, wanted)
+	res := syncResult{Added: []string{}, Removed: []string{}}
+
+	for _, term := range add {
+		if err = addTerm(r.Context(), channel, term, 0); err != nil {
+			http.Error(w, fmt.Errorf("adding term: %w", err).Error(), http.StatusInternalServerError)
+			return
+		}
+		res.Added = append(res.Added, term)
+	}
+
+	for _, bt := range keep {
+		// Listed terms are permanent, even if they were added with an
+		// expiry before
+		if err = deleteExpiry(db, bt.ID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if !keepUnlisted {
+		for _, bt := range remove {
+			if err = botTwitchClient().RemoveBlockedTerm(r.Context(), channel, bt.ID); err != nil {
+				http.Error(w, fmt.Errorf("removing term: %w", err).Error(), http.StatusInternalServerError)
+				return
+			}
+
+			if err = deleteExpiry(db, bt.ID); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			res.Removed = append(res.Removed, bt.Text)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, fmt.Errorf("encoding response: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// diffTerms compares the current blocked terms against the wanted
+// list (case-insensitive as Twitch matches terms case-insensitive)
+// and returns the terms to add, the existing terms to keep and the
+// existing terms to remove
+func diffTerms(current []twitch.BlockedTerm, wanted []string) (add []string, keep, remove []twitch.BlockedTerm) {
+	existing := make(map[string]bool, len(current))
+	wantedSet := make(map[string]bool, len(wanted))
+
+	for _, term := range wanted {
+		wantedSet[strings.ToLower(term)] = true
+	}
+
+	for _, bt := range current {
+		existing[strings.ToLower(bt.Text)] = true
+
+		if wantedSet[strings.ToLower(bt.Text)] {
+			keep = append(keep, bt)
+		} else {
+			remove = append(remove, bt)
+		}
+	}
+
+	for _, term := range wanted {
+		if !existing[strings.ToLower(term)] {
+			add = append(add, term)
+			existing[strings.ToLower(term)] = true // Prevent duplicates within the list
+		}
+	}
+
+	return add, keep, remove
+}
+
+// parseTermList reads one term per line ignoring empty lines and lines
+// starting with #
+func parseTermList(r io.Reader) (terms []string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		terms = append(terms, line)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading terms: %w", err)
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,75 @@
+package blockedterms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+)
+
+func TestDiffTerms(t *testing.T) {
+	var (
+		foo = twitch.BlockedTerm{ID: "1", Text: "foo"}
+		bar = twitch.BlockedTerm{ID: "2", Text: "Bar"}
+	)
+
+	for name, tc := range map[string]struct {
+		current   []twitch.BlockedTerm
+		wanted    []string
+		expAdd    []string
+		expKeep   []twitch.BlockedTerm
+		expRemove []twitch.BlockedTerm
+	}{
+		"empty": {},
+		"add all": {
+			wanted: []string{"foo", "bar"},
+			expAdd: []string{"foo", "bar"},
+		},
+		"remove all": {
+			current:   []twitch.BlockedTerm{foo, bar},
+			expRemove: []twitch.BlockedTerm{foo, bar},
+		},
+		"case-insensitive keep": {
+			current: []twitch.BlockedTerm{foo, bar},
+			wanted:  []string{"FOO", "bar"},
+			expKeep: []twitch.BlockedTerm{foo, bar},
+		},
+		"mixed": {
+			current:   []twitch.BlockedTerm{foo, bar},
+			wanted:    []string{"bar", "baz"},
+			expAdd:    []string{"baz"},
+			expKeep:   []twitch.BlockedTerm{bar},
+			expRemove: []twitch.BlockedTerm{foo},
+		},
+		"duplicates in list": {
+			wanted: []string{"baz", "BAZ", "baz"},
+			expAdd: []string{"baz"},
+		},
+	} {
+		add, keep, remove := diffTerms(tc.current, tc.wanted)
+		assert.Equal(t, tc.expAdd, add, name)
+		assert.Equal(t, tc.expKeep, keep, name)
+		assert.Equal(t, tc.expRemove, remove, name)
+	}
+}
+
+func TestParseTermList(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input string
+		exp   []string
+	}{
+		"empty":         {input: "", exp: nil},
+		"comments only": {input: "# a comment\n\n  # indented comment\n", exp: nil},
+		"terms": {
+			input: "foo\n\n# comment\n  bar baz  \r\nqux",
+			exp:   []string{"foo", "bar baz", "qux"},
+		},
+	} {
+		terms, err := parseTermList(strings.NewReader(tc.input))
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.exp, terms, name)
+	}
+}
@@ -0,0 +1,80 @@
+// Package callactions contains an actor to execute a named action set
+// defined in the bot configuration
+package callactions
+
+import (
+	"fmt"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const actorName = "call_actions"
+
+type actor struct{}
+
+var (
+	executeActions plugins.ActionExecutionFunc
+	getActionSet   plugins.ActionSetGetterFunc
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	executeActions = args.ExecuteActions
+	getActionSet = args.GetActionSet
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Execute the actions of a named action set (defined in `action_sets` of the config) with the current event data",
+		Name:        "Call Action Set",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Name of the action set to execute",
+				Key:             "set",
+				Name:            "Set",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(c *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	set := attrs.MustString("set", nil)
+
+	actions, err := getActionSet(set)
+	if err != nil {
+		return false, fmt.Errorf("getting action set: %w", err)
+	}
+
+	if preventCooldown, err = executeActions(c, m, r, actions, eventData); err != nil {
+		return preventCooldown, fmt.Errorf("executing action set %q: %w", set, err)
+	}
+
+	return preventCooldown, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(_ plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	// Existence of the set is checked when loading the config as the
+	// set might be added together with the action referencing it
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "set", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	return nil
+}
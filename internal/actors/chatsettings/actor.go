@@ -0,0 +1,332 @@
+// Package chatsettings contains an actor to update the chat settings
+// (slow, followers-only, emote-only, sub-only, unique-chat) of a
+// channel with an optional timed revert
+package chatsettings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/irc.v4"
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actorName = "chatsettings"
+
+	defaultSlowModeWait = 30 * time.Second
+	maxFollowerDuration = 129600 * time.Minute // 3 months
+	maxSlowModeWait     = 120 * time.Second
+	minSlowModeWait     = 3 * time.Second
+
+	settingEmoteMode      = "emote_mode"
+	settingFollowerMode   = "follower_mode"
+	settingSlowMode       = "slow_mode"
+	settingSubscriberMode = "subscriber_mode"
+	settingUniqueChatMode = "unique_chat_mode"
+)
+
+type actor struct{}
+
+var (
+	botTwitchClient func() *twitch.Client
+	db              database.Connector
+	logger          *logrus.Entry
+
+	settingAttributes = []string{"emote_only", "followers_only", "slow_mode", "sub_only", "unique_chat"}
+)
+
+// Register provides the plugins.RegisterFunc
+//
+//nolint:funlen // This function is a few lines too long but only contains definitions
+func Register(args plugins.RegistrationArguments) (err error) {
+	db = args.GetDatabaseConnector()
+	if err = db.DB().AutoMigrate(&chatSettingsRevert{}); err != nil {
+		return fmt.Errorf("applying schema migration: %w", err)
+	}
+
+	args.RegisterCopyDatabaseFunc("chatsettings", func(src, target *gorm.DB) error {
+		return database.CopyObjects(src, target, &chatSettingsRevert{})
+	})
+
+	botTwitchClient = args.GetTwitchClient
+	logger = args.GetLogger(actorName)
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Update chat settings for the given channel (only the given settings are changed)",
+		Name:        "Update Chat Settings",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Enable or disable emote-only mode",
+				Key:             "emote_only",
+				Name:            "Emote-Only",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         "",
+				Description:     "Enable or disable followers-only mode",
+				Key:             "followers_only",
+				Name:            "Followers-Only",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         "0s",
+				Description:     "How long users must have followed to chat in followers-only mode (minute precision, max 3 months)",
+				Key:             "followers_only_duration",
+				Name:            "Followers-Only Duration",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+			{
+				Default:         "0s",
+				Description:     "Revert the changed settings to their previous values after this duration (0s = keep the settings)",
+				Key:             "revert_after",
+				Name:            "Revert After",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+			{
+				Default:         "",
+				Description:     "Enable or disable slow mode",
+				Key:             "slow_mode",
+				Name:            "Slow Mode",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         defaultSlowModeWait.String(),
+				Description:     "How long users must wait between messages in slow mode (3s - 120s)",
+				Key:             "slow_mode_wait",
+				Name:            "Slow Mode Wait",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+			{
+				Default:         "",
+				Description:     "Enable or disable subscribers-only mode",
+				Key:             "sub_only",
+				Name:            "Sub-Only",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         "",
+				Description:     "Enable or disable unique-chat mode",
+				Key:             "unique_chat",
+				Name:            "Unique-Chat",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+		},
+	})
+
+	if _, err = args.RegisterCron("@every 10s", revertDueSettings); err != nil {
+		return fmt.Errorf("registering revert cron: %w", err)
+	}
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, _ *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	var (
+		channel  = plugins.DeriveChannel(m, eventData)
+		ctx      = context.Background()
+		settings = settingsFromAttributes(attrs)
+		changed  = splitSettings(settings)
+	)
+
+	revertAfter := attrs.MustDuration("revert_after", new(time.Duration(0)))
+
+	var previous map[string]twitch.ChatSettings
+	if revertAfter > 0 {
+		// Needs to be fetched before the update to know what to revert to
+		current, err := botTwitchClient().GetChatSettings(ctx, channel)
+		if err != nil {
+			return false, fmt.Errorf("getting current chat settings: %w", err)
+		}
+
+		previous = splitSettings(current)
+	}
+
+	// Reverts must only be touched after the update succeeded: otherwise
+	// a failed update would later overwrite whatever was set meanwhile
+	if err = botTwitchClient().UpdateChatSettings(ctx, channel, settings); err != nil {
+		return false, fmt.Errorf("updating chat settings: %w", err)
+	}
+
+	if revertAfter > 0 {
+		for setting := range changed {
+			value, err := json.Marshal(previous[setting])
+			if err != nil {
+				return false, fmt.Errorf("encoding previous value: %w", err)
+			}
+
+			if err = scheduleRevert(db, channel, setting, string(value), time.Now().Add(revertAfter)); err != nil {
+				return false, fmt.Errorf("scheduling revert: %w", err)
+			}
+		}
+
+		return false, nil
+	}
+
+	// An explicit change overrides pending reverts of the same setting
+	settingKeys := make([]string, 0, len(changed))
+	for setting := range changed {
+		settingKeys = append(settingKeys, setting)
+	}
+
+	if err = deleteReverts(db, channel, settingKeys...); err != nil {
+		return false, fmt.Errorf("removing pending reverts: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(_ plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "emote_only", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "followers_only", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "followers_only_duration", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "revert_after", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "slow_mode", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "slow_mode_wait", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "sub_only", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "unique_chat", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.MustHaveNoUnknowFields,
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	if !slices.ContainsFunc(settingAttributes, func(key string) bool { return attrs.HasAll(key) }) {
+		return errors.New("at least one chat setting must be given")
+	}
+
+	if d := attrs.MustDuration("followers_only_duration", new(time.Duration(0))); d < 0 || d > maxFollowerDuration {
+		return fmt.Errorf("followers_only_duration must be between 0s and %s", maxFollowerDuration)
+	}
+
+	if d := attrs.MustDuration("slow_mode_wait", new(defaultSlowModeWait)); d < minSlowModeWait || d > maxSlowModeWait {
+		return fmt.Errorf("slow_mode_wait must be between %s and %s", minSlowModeWait, maxSlowModeWait)
+	}
+
+	return nil
+}
+
+func revertDueSettings() {
+	reverts, err := getDueReverts(db, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("fetching due chat settings reverts")
+		return
+	}
+
+	var (
+		channelSettings = make(map[string]*twitch.ChatSettings)
+		channelKeys     = make(map[string][]string)
+	)
+
+	for _, r := range reverts {
+		if channelSettings[r.Channel] == nil {
+			channelSettings[r.Channel] = new(twitch.ChatSettings)
+		}
+
+		// Each value only contains the fields of its setting so
+		// decoding them into the same struct merges them
+		if err = json.Unmarshal([]byte(r.Value), channelSettings[r.Channel]); err != nil {
+			logger.WithError(err).WithField("channel", r.Channel).Error("decoding chat settings revert")
+			continue
+		}
+
+		channelKeys[r.Channel] = append(channelKeys[r.Channel], r.Setting)
+	}
+
+	for channel, settings := range channelSettings {
+		if err = botTwitchClient().UpdateChatSettings(context.Background(), channel, *settings); err != nil {
+			logger.WithError(err).WithField("channel", channel).Error("reverting chat settings")
+			continue
+		}
+
+		if err = deleteReverts(db, channel, channelKeys[channel]...); err != nil {
+			logger.WithError(err).WithField("channel", channel).Error("removing executed chat settings reverts")
+		}
+	}
+}
+
+func settingsFromAttributes(attrs *fieldcollection.FieldCollection) (s twitch.ChatSettings) {
+	for key, target := range map[string]**bool{
+		"emote_only":     &s.EmoteMode,
+		"followers_only": &s.FollowerMode,
+		"slow_mode":      &s.SlowMode,
+		"sub_only":       &s.SubscriberMode,
+		"unique_chat":    &s.UniqueChatMode,
+	} {
+		if attrs.HasAll(key) {
+			*target = new(attrs.MustBool(key, new(false)))
+		}
+	}
+
+	if s.FollowerMode != nil && *s.FollowerMode {
+		s.FollowerModeDuration = new(int64(attrs.MustDuration("followers_only_duration", new(time.Duration(0))) / time.Minute))
+	}
+
+	if s.SlowMode != nil && *s.SlowMode {
+		s.SlowModeWaitTime = new(int64(attrs.MustDuration("slow_mode_wait", new(defaultSlowModeWait)) / time.Second))
+	}
+
+	return s
+}
+
+// splitSettings splits the given settings into one settings object per
+// setting containing only the fields belonging to that setting
+func splitSettings(s twitch.ChatSettings) map[string]twitch.ChatSettings {
+	out := make(map[string]twitch.ChatSettings)
+
+	if s.EmoteMode != nil {
+		out[settingEmoteMode] = twitch.ChatSettings{EmoteMode: s.EmoteMode}
+	}
+
+	if s.FollowerMode != nil {
+		out[settingFollowerMode] = twitch.ChatSettings{FollowerMode: s.FollowerMode, FollowerModeDuration: s.FollowerModeDuration}
+	}
+
+	if s.SlowMode != nil {
+		out[settingSlowMode] = twitch.ChatSettings{SlowMode: s.SlowMode, SlowModeWaitTime: s.SlowModeWaitTime}
+	}
+
+	if s.SubscriberMode != nil {
+		out[settingSubscriberMode] = twitch.ChatSettings{SubscriberMode: s.SubscriberMode}
+	}
+
+	if s.UniqueChatMode != nil {
+		out[settingUniqueChatMode] = twitch.ChatSettings{UniqueChatMode: s.UniqueChatMode}
+	}
+
+	return out
+}
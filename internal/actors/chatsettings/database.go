@@ -0,0 +1,87 @@
+package chatsettings
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+type (
+	// chatSettingsRevert stores the value a chat setting had before a
+	// timed change in order to restore it after the change expired
+	chatSettingsRevert struct {
+		Channel string `gorm:"primaryKey"`
+		Setting string `gorm:"primaryKey"`
+		// Value contains the JSON encoded twitch.ChatSettings with only
+		// the fields of the setting present
+		Value    string
+		RevertAt time.Time `gorm:"index"`
+	}
+)
+
+func deleteReverts(db database.Connector, channel string, settings ...string) error {
+	if len(settings) == 0 {
+		return nil
+	}
+
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		return tx.Delete(&chatSettingsRevert{}, "channel = ? AND setting IN ?", channel, settings).Error
+	}); err != nil {
+		return fmt.Errorf("deleting reverts: %w", err)
+	}
+
+	return nil
+}
+
+func getDueReverts(db database.Connector, at time.Time) (reverts []chatSettingsRevert, err error) {
+	if err = helpers.Retry(func() error {
+		return db.DB().
+			Where("revert_at <= ?", at.UTC()).
+			Order("channel").
+			Find(&reverts).
+			Error
+	}); err != nil {
+		return nil, fmt.Errorf("querying due reverts: %w", err)
+	}
+
+	return reverts, nil
+}
+
+// scheduleRevert stores the value to revert the setting to. If there
+// already is a pending revert for the setting its original value is
+// kept and only the revert time is extended as the current value is
+// the one set by the previous timed change.
+func scheduleRevert(db database.Connector, channel, setting, value string, revertAt time.Time) error {
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		var existing chatSettingsRevert
+
+		err := tx.First(&existing, "channel = ? AND setting = ?", channel, setting).Error
+		switch {
+		case err == nil:
+			if revertAt.UTC().After(existing.RevertAt) {
+				existing.RevertAt = revertAt.UTC()
+			}
+			return tx.Save(&existing).Error
+
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return tx.Create(&chatSettingsRevert{
+				Channel:  channel,
+				Setting:  setting,
+				Value:    value,
+				RevertAt: revertAt.UTC(),
+			}).Error
+
+		default:
+			return fmt.Errorf("fetching existing revert: %w", err)
+		}
+	}); err != nil {
+		return fmt.Errorf("storing revert: %w", err)
+	}
+
+	return nil
+}
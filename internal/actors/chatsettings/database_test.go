@@ -0,0 +1,45 @@
+package chatsettings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+func TestRevertRoundtrip(t *testing.T) {
+	dbc := database.GetTestDatabase(t)
+	require.NoError(t, dbc.DB().AutoMigrate(&chatSettingsRevert{}))
+
+	var (
+		channel = "#test"
+		now     = time.Now()
+	)
+
+	require.NoError(t, scheduleRevert(dbc, channel, "emote_mode", `{"emote_mode":false}`, now.Add(time.Minute)))
+
+	reverts, err := getDueReverts(dbc, now)
+	require.NoError(t, err)
+	assert.Empty(t, reverts, "revert must not be due yet")
+
+	// Second timed change must keep the original value but extend the time
+	require.NoError(t, scheduleRevert(dbc, channel, "emote_mode", `{"emote_mode":true}`, now.Add(2*time.Minute)))
+
+	reverts, err = getDueReverts(dbc, now.Add(90*time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, reverts, "revert must have been extended")
+
+	reverts, err = getDueReverts(dbc, now.Add(3*time.Minute))
+	require.NoError(t, err)
+	require.Len(t, reverts, 1)
+	assert.Equal(t, `{"emote_mode":false}`, reverts[0].Value)
+
+	require.NoError(t, deleteReverts(dbc, channel, "emote_mode"))
+
+	reverts, err = getDueReverts(dbc, now.Add(3*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, reverts)
+}
@@ -0,0 +1,121 @@
+// Package conditional contains an actor to branch the rule execution
+// into nested action lists based on a template condition
+package conditional
+
+import (
+	"fmt"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const actorName = "conditional"
+
+type actor struct{}
+
+var (
+	executeActions  plugins.ActionExecutionFunc
+	formatMessage   plugins.MsgFormatter
+	validateActions plugins.ActionValidationFunc
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	executeActions = args.ExecuteActions
+	formatMessage = args.FormatMessage
+	validateActions = args.ValidateActions
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Execute one of two action lists depending on a condition (use another conditional in `else` to build else-if chains)",
+		Name:        "Conditional Branch",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Condition when to execute the `then` actions (must evaluate to \"true\", otherwise the `else` actions are executed)",
+				Key:             "when",
+				Name:            "When",
+				Optional:        false,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Actions to execute when the condition is met",
+				Key:             "then",
+				Name:            "Then",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeActionList,
+			},
+			{
+				Default:         "",
+				Description:     "Actions to execute when the condition is not met",
+				Key:             "else",
+				Name:            "Else",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeActionList,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(c *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	when, err := formatMessage(attrs.MustString("when", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing when template: %w", err)
+	}
+
+	branch := "else"
+	if when == "true" {
+		branch = "then"
+	}
+
+	actions, err := plugins.ParseRuleActions(attrs, branch)
+	if err != nil {
+		return false, fmt.Errorf("parsing %s actions: %w", branch, err)
+	}
+
+	if preventCooldown, err = executeActions(c, m, r, actions, eventData); err != nil {
+		return preventCooldown, fmt.Errorf("executing %s actions: %w", branch, err)
+	}
+
+	return preventCooldown, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "when", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "then", Type: fieldcollection.SchemaFieldTypeAny}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "else", Type: fieldcollection.SchemaFieldTypeAny}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "when"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	for _, branch := range []string{"then", "else"} {
+		actions, err := plugins.ParseRuleActions(attrs, branch)
+		if err != nil {
+			return fmt.Errorf("parsing %s actions: %w", branch, err)
+		}
+
+		if err = validateActions(actions); err != nil {
+			return fmt.Errorf("validating %s actions: %w", branch, err)
+		}
+	}
+
+	return nil
+}
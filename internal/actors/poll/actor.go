@@ -0,0 +1,308 @@
+// Package poll contains an actor to create and end polls in a channel
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actorName = "poll"
+
+	choiceSeparator = "|"
+	defaultDuration = time.Minute
+	maxChoices      = 5
+	maxDuration     = 30 * time.Minute
+	minChoices      = 2
+	minDuration     = 15 * time.Second
+
+	operationArchive = "archive"
+	operationCreate  = "create"
+	operationEnd     = "end"
+)
+
+type actor struct{}
+
+var (
+	formatMessage plugins.MsgFormatter
+	permCheckFn   plugins.ChannelPermissionCheckFunc
+	tcGetter      func(string) (*twitch.Client, error)
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	formatMessage = args.FormatMessage
+	permCheckFn = args.HasPermissionForChannel
+	tcGetter = args.GetTwitchClientForChannel
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Create or end a poll in the current channel (the ID of a created poll is stored as `poll_id` in the event data for following actions)",
+		Name:        "Poll",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "What to do with the poll: `create`, `end` (results stay visible) or `archive` (end and hide results)",
+				Key:             "operation",
+				Name:            "Operation",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Title of the poll to create (required for `create`)",
+				Key:             "title",
+				Name:            "Title",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Choices of the poll to create (2-5, required for `create`, each entry may render to multiple choices separated by `|`)",
+				Key:             "choices",
+				Name:            "Choices",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeStringSlice,
+			},
+			{
+				Default:         defaultDuration.String(),
+				Description:     "How long the poll runs (15s-30m, Go duration like `2m` or number of seconds, used for `create`)",
+				Key:             "duration",
+				Name:            "Duration",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "0",
+				Description:     "Channel points per additional vote (`0` disables channel-point voting, used for `create`)",
+				Key:             "channel_points_per_vote",
+				Name:            "Channel Points per Vote",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeInt64,
+			},
+			{
+				Default:         "",
+				Description:     "ID of the poll to end (used for `end` / `archive`, defaults to the `poll_id` in the event data or the currently running poll)",
+				Key:             "poll_id",
+				Name:            "Poll ID",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	channel := strings.TrimLeft(plugins.DeriveChannel(m, eventData), "#")
+
+	ok, err := permCheckFn(channel, twitch.ScopeChannelManagePolls)
+	if err != nil {
+		return false, fmt.Errorf("checking for channel permissions: %w", err)
+	}
+
+	if !ok {
+		return false, fmt.Errorf("channel %q is missing permission %s", channel, twitch.ScopeChannelManagePolls)
+	}
+
+	tc, err := tcGetter(channel)
+	if err != nil {
+		return false, fmt.Errorf("getting channel twitch-client: %w", err)
+	}
+
+	switch attrs.MustString("operation", nil) {
+	case operationArchive:
+		return false, endPoll(tc, channel, twitch.PollStatusArchived, m, r, eventData, attrs)
+
+	case operationCreate:
+		return false, createPoll(tc, channel, m, r, eventData, attrs)
+
+	case operationEnd:
+		return false, endPoll(tc, channel, twitch.PollStatusTerminated, m, r, eventData, attrs)
+
+	default:
+		return false, fmt.Errorf("unknown operation %q", attrs.MustString("operation", nil))
+	}
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "operation", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "title", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "choices", Type: fieldcollection.SchemaFieldTypeStringSlice}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "duration", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "channel_points_per_vote", Type: fieldcollection.SchemaFieldTypeInt64}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "poll_id", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "title", "duration", "poll_id"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	for i, el := range attrs.MustStringSlice("choices", new([]string)) {
+		if err = tplValidator(el); err != nil {
+			return fmt.Errorf("validating choice template (element %d): %w", i, err)
+		}
+	}
+
+	switch attrs.MustString("operation", nil) {
+	case operationArchive, operationEnd:
+		// No further requirements
+
+	case operationCreate:
+		if attrs.MustString("title", new("")) == "" {
+			return errors.New("title is required for create")
+		}
+
+		if len(attrs.MustStringSlice("choices", new([]string))) == 0 {
+			return errors.New("choices are required for create")
+		}
+
+		if attrs.MustInt64("channel_points_per_vote", new(int64(0))) < 0 {
+			return errors.New("channel_points_per_vote must not be negative")
+		}
+
+	default:
+		return fmt.Errorf("unknown operation %q", attrs.MustString("operation", nil))
+	}
+
+	return nil
+}
+
+func createPoll(
+	tc *twitch.Client,
+	channel string,
+	m *irc.Message,
+	r *plugins.Rule,
+	eventData *fieldcollection.FieldCollection,
+	attrs *fieldcollection.FieldCollection,
+) error {
+	title, err := formatMessage(attrs.MustString("title", nil), m, r, eventData)
+	if err != nil {
+		return fmt.Errorf("executing title template: %w", err)
+	}
+
+	var choices []string
+	for i, tpl := range attrs.MustStringSlice("choices", nil) {
+		rendered, err := formatMessage(tpl, m, r, eventData)
+		if err != nil {
+			return fmt.Errorf("executing choice template (element %d): %w", i, err)
+		}
+
+		for _, choice := range strings.Split(rendered, choiceSeparator) {
+			if choice = strings.TrimSpace(choice); choice != "" {
+				choices = append(choices, choice)
+			}
+		}
+	}
+
+	if len(choices) < minChoices || len(choices) > maxChoices {
+		return fmt.Errorf("poll requires %d-%d choices, got %d", minChoices, maxChoices, len(choices))
+	}
+
+	durationStr, err := formatMessage(attrs.MustString("duration", new(defaultDuration.String())), m, r, eventData)
+	if err != nil {
+		return fmt.Errorf("executing duration template: %w", err)
+	}
+
+	duration, err := parseDuration(durationStr)
+	if err != nil {
+		return err
+	}
+
+	poll, err := tc.CreatePoll(
+		context.Background(),
+		channel,
+		title,
+		choices,
+		duration,
+		attrs.MustInt64("channel_points_per_vote", new(int64(0))),
+	)
+	if err != nil {
+		return fmt.Errorf("creating poll: %w", err)
+	}
+
+	eventData.Set("poll_id", poll.ID)
+
+	return nil
+}
+
+func endPoll(
+	tc *twitch.Client,
+	channel, status string,
+	m *irc.Message,
+	r *plugins.Rule,
+	eventData *fieldcollection.FieldCollection,
+	attrs *fieldcollection.FieldCollection,
+) error {
+	pollID, err := formatMessage(attrs.MustString("poll_id", new("")), m, r, eventData)
+	if err != nil {
+		return fmt.Errorf("executing poll_id template: %w", err)
+	}
+
+	if pollID == "" {
+		pollID = eventData.MustString("poll_id", new(""))
+	}
+
+	if pollID == "" {
+		poll, err := tc.GetLatestPoll(context.Background(), channel)
+		if err != nil {
+			return fmt.Errorf("getting current poll: %w", err)
+		}
+
+		if poll.Status != twitch.PollStatusActive {
+			return errors.New("no poll running")
+		}
+
+		pollID = poll.ID
+	}
+
+	if _, err = tc.EndPoll(context.Background(), channel, pollID, status); err != nil {
+		return fmt.Errorf("ending poll: %w", err)
+	}
+
+	return nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		secs, serr := strconv.ParseInt(value, 10, 64)
+		if serr != nil {
+			return 0, fmt.Errorf("parsing duration %q: %w", value, err)
+		}
+		d = time.Duration(secs) * time.Second
+	}
+
+	if d < minDuration || d > maxDuration {
+		return 0, fmt.Errorf("duration must be between %s and %s", minDuration, maxDuration)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,178 @@
+package poll
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, every user has the ID 123 and poll requests are
+// answered by the given handler
+func setupTestActor(t *testing.T, polls http.HandlerFunc) {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"testchannel"}]}`)
+
+		default:
+			polls(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+	permCheckFn = func(string, ...string) (bool, error) { return true, nil }
+	tcGetter = func(string) (*twitch.Client, error) { return tc, nil }
+}
+
+func TestExecute(t *testing.T) {
+	type pollRequest struct {
+		method string
+		body   map[string]any
+	}
+
+	for name, tc := range map[string]struct {
+		attrs     map[string]any
+		eventData map[string]any
+		expErr    bool
+		expReqs   []pollRequest
+		expPollID string
+	}{
+		"create with split choices": {
+			attrs: map[string]any{"operation": "create", "title": "Next?", "choices": []any{"A | B", "C"}, "duration": "90"},
+			expReqs: []pollRequest{{http.MethodPost, map[string]any{
+				"broadcaster_id":                "123",
+				"title":                         "Next?",
+				"choices":                       []any{map[string]any{"title": "A"}, map[string]any{"title": "B"}, map[string]any{"title": "C"}},
+				"duration":                      float64(90),
+				"channel_points_voting_enabled": false,
+			}}},
+			expPollID: "poll",
+		},
+		"create too few choices": {
+			attrs:  map[string]any{"operation": "create", "title": "Next?", "choices": []any{"A|"}},
+			expErr: true,
+		},
+		"create invalid duration": {
+			attrs:  map[string]any{"operation": "create", "title": "Next?", "choices": []any{"A", "B"}, "duration": "5s"},
+			expErr: true,
+		},
+		"end given poll": {
+			attrs:   map[string]any{"operation": "end", "poll_id": "given"},
+			expReqs: []pollRequest{{http.MethodPatch, map[string]any{"broadcaster_id": "123", "id": "given", "status": twitch.PollStatusTerminated}}},
+		},
+		"archive poll from event": {
+			attrs:     map[string]any{"operation": "archive"},
+			eventData: map[string]any{"poll_id": "event"},
+			expReqs:   []pollRequest{{http.MethodPatch, map[string]any{"broadcaster_id": "123", "id": "event", "status": twitch.PollStatusArchived}}},
+			expPollID: "event",
+		},
+		"end running poll": {
+			attrs: map[string]any{"operation": "end"},
+			expReqs: []pollRequest{
+				{http.MethodGet, nil},
+				{http.MethodPatch, map[string]any{"broadcaster_id": "123", "id": "poll", "status": twitch.PollStatusTerminated}},
+			},
+		},
+	} {
+		var reqs []pollRequest
+		setupTestActor(t, func(w http.ResponseWriter, r *http.Request) {
+			req := pollRequest{method: r.Method}
+			if r.Method != http.MethodGet {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req.body))
+			}
+			reqs = append(reqs, req)
+
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"id": "poll", "status": twitch.PollStatusActive}},
+			}))
+		})
+
+		eventData := fieldcollection.FromData(tc.eventData)
+		_, err := actor{}.Execute(nil, nil, nil, eventData, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+			assert.Empty(t, reqs, name)
+			continue
+		}
+
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expReqs, reqs, name)
+		assert.Equal(t, tc.expPollID, eventData.MustString("poll_id", new("")), name)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for value, exp := range map[string]time.Duration{
+		"1m":   time.Minute,
+		" 90 ": 90 * time.Second,
+		"15s":  15 * time.Second,
+		"30m":  30 * time.Minute,
+	} {
+		d, err := parseDuration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, exp, d, value)
+	}
+
+	for _, value := range []string{"", "soon", "10s", "31m", "0"} {
+		_, err := parseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"create":               {map[string]any{"operation": "create", "title": "Next?", "choices": []any{"A|B"}}, false},
+		"create no title":      {map[string]any{"operation": "create", "choices": []any{"A", "B"}}, true},
+		"create no choices":    {map[string]any{"operation": "create", "title": "Next?"}, true},
+		"create negative cost": {map[string]any{"operation": "create", "title": "Next?", "choices": []any{"A", "B"}, "channel_points_per_vote": -1}, true},
+		"end":                  {map[string]any{"operation": "end"}, false},
+		"archive":              {map[string]any{"operation": "archive", "poll_id": "{{ .poll_id }}"}, false},
+		"unknown operation":    {map[string]any{"operation": "start"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
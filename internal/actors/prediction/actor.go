@@ -0,0 +1,275 @@
+// Package prediction contains an actor to create, lock, resolve or
+// cancel predictions in a channel
+package prediction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actorName = "prediction"
+
+	defaultPredictionWindow = 2 * time.Minute
+	maxOutcomes             = 10
+	maxPredictionWindow     = 30 * time.Minute
+	minOutcomes             = 2
+	minPredictionWindow     = 30 * time.Second
+
+	operationCancel  = "cancel"
+	operationCreate  = "create"
+	operationLock    = "lock"
+	operationResolve = "resolve"
+)
+
+type actor struct{}
+
+var (
+	formatMessage plugins.MsgFormatter
+	permCheckFn   plugins.ChannelPermissionCheckFunc
+	tcGetter      func(string) (*twitch.Client, error)
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	formatMessage = args.FormatMessage
+	permCheckFn = args.HasPermissionForChannel
+	tcGetter = args.GetTwitchClientForChannel
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Create, lock, resolve or cancel a prediction in the current channel (lock, resolve and cancel act on the currently running prediction)",
+		Name:        "Prediction",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "What to do with the prediction: `create`, `lock`, `resolve` or `cancel`",
+				Key:             "operation",
+				Name:            "Operation",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Title of the prediction to create (required for `create`)",
+				Key:             "title",
+				Name:            "Title",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Outcomes of the prediction to create (2-10, required for `create`)",
+				Key:             "outcomes",
+				Name:            "Outcomes",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeStringSlice,
+			},
+			{
+				Default:         defaultPredictionWindow.String(),
+				Description:     "How long viewers can make predictions (30s-30m, used for `create`)",
+				Key:             "duration",
+				Name:            "Duration",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+			{
+				Default:         "",
+				Description:     "Title or 1-based number of the winning outcome (required for `resolve`)",
+				Key:             "winning_outcome",
+				Name:            "Winning Outcome",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	channel := strings.TrimLeft(plugins.DeriveChannel(m, eventData), "#")
+
+	ok, err := permCheckFn(channel, twitch.ScopeChannelManagePredictions)
+	if err != nil {
+		return false, fmt.Errorf("checking for channel permissions: %w", err)
+	}
+
+	if !ok {
+		return false, fmt.Errorf("channel %q is missing permission %s", channel, twitch.ScopeChannelManagePredictions)
+	}
+
+	tc, err := tcGetter(channel)
+	if err != nil {
+		return false, fmt.Errorf("getting channel twitch-client: %w", err)
+	}
+
+	operation := attrs.MustString("operation", nil)
+	if operation == operationCreate {
+		return false, createPrediction(tc, channel, m, r, eventData, attrs)
+	}
+
+	prediction, err := tc.GetLatestPrediction(context.Background(), channel)
+	if err != nil {
+		return false, fmt.Errorf("getting current prediction: %w", err)
+	}
+
+	if prediction.Status != twitch.PredictionStatusActive && prediction.Status != twitch.PredictionStatusLocked {
+		return false, errors.New("no prediction running")
+	}
+
+	var status, winningOutcomeID string
+
+	switch operation {
+	case operationCancel:
+		status = twitch.PredictionStatusCanceled
+
+	case operationLock:
+		if prediction.Status == twitch.PredictionStatusLocked {
+			// Already locked, nothing to do
+			return false, nil
+		}
+		status = twitch.PredictionStatusLocked
+
+	case operationResolve:
+		status = twitch.PredictionStatusResolved
+
+		winner, err := formatMessage(attrs.MustString("winning_outcome", new("")), m, r, eventData)
+		if err != nil {
+			return false, fmt.Errorf("executing winning_outcome template: %w", err)
+		}
+
+		if winningOutcomeID, err = findOutcomeID(prediction, winner); err != nil {
+			return false, err
+		}
+
+	default:
+		return false, fmt.Errorf("unknown operation %q", operation)
+	}
+
+	if _, err = tc.EndPrediction(context.Background(), channel, prediction.ID, status, winningOutcomeID); err != nil {
+		return false, fmt.Errorf("updating prediction: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "operation", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "title", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "outcomes", Type: fieldcollection.SchemaFieldTypeStringSlice}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "duration", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "winning_outcome", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "title", "winning_outcome"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	for i, el := range attrs.MustStringSlice("outcomes", new([]string)) {
+		if err = tplValidator(el); err != nil {
+			return fmt.Errorf("validating outcome template (element %d): %w", i, err)
+		}
+	}
+
+	switch attrs.MustString("operation", nil) {
+	case operationCancel, operationLock:
+		// No further requirements
+
+	case operationCreate:
+		if attrs.MustString("title", new("")) == "" {
+			return errors.New("title is required for create")
+		}
+
+		if n := len(attrs.MustStringSlice("outcomes", new([]string))); n < minOutcomes || n > maxOutcomes {
+			return fmt.Errorf("create requires %d-%d outcomes", minOutcomes, maxOutcomes)
+		}
+
+		if d := attrs.MustDuration("duration", new(defaultPredictionWindow)); d < minPredictionWindow || d > maxPredictionWindow {
+			return fmt.Errorf("duration must be between %s and %s", minPredictionWindow, maxPredictionWindow)
+		}
+
+	case operationResolve:
+		if attrs.MustString("winning_outcome", new("")) == "" {
+			return errors.New("winning_outcome is required for resolve")
+		}
+
+	default:
+		return fmt.Errorf("unknown operation %q", attrs.MustString("operation", nil))
+	}
+
+	return nil
+}
+
+func createPrediction(
+	tc *twitch.Client,
+	channel string,
+	m *irc.Message,
+	r *plugins.Rule,
+	eventData *fieldcollection.FieldCollection,
+	attrs *fieldcollection.FieldCollection,
+) error {
+	title, err := formatMessage(attrs.MustString("title", nil), m, r, eventData)
+	if err != nil {
+		return fmt.Errorf("executing title template: %w", err)
+	}
+
+	var outcomes []string
+	for i, tpl := range attrs.MustStringSlice("outcomes", nil) {
+		outcome, err := formatMessage(tpl, m, r, eventData)
+		if err != nil {
+			return fmt.Errorf("executing outcome template (element %d): %w", i, err)
+		}
+
+		outcomes = append(outcomes, outcome)
+	}
+
+	if _, err = tc.CreatePrediction(
+		context.Background(),
+		channel,
+		title,
+		outcomes,
+		attrs.MustDuration("duration", new(defaultPredictionWindow)),
+	); err != nil {
+		return fmt.Errorf("creating prediction: %w", err)
+	}
+
+	return nil
+}
+
+func findOutcomeID(prediction *twitch.PredictionInfo, winner string) (string, error) {
+	for _, o := range prediction.Outcomes {
+		if strings.EqualFold(o.Title, winner) {
+			return o.ID, nil
+		}
+	}
+
+	if idx, err := strconv.Atoi(winner); err == nil && idx > 0 && idx <= len(prediction.Outcomes) {
+		return prediction.Outcomes[idx-1].ID, nil
+	}
+
+	return "", fmt.Errorf("winning outcome %q not found in prediction", winner)
+}
@@ -0,0 +1,65 @@
+package prediction
+
+import (
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+)
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"create":              {map[string]any{"operation": "create", "title": "Boss?", "outcomes": []any{"Yes", "No"}}, false},
+		"create with window":  {map[string]any{"operation": "create", "title": "Boss?", "outcomes": []any{"Yes", "No"}, "duration": "5m"}, false},
+		"create no title":     {map[string]any{"operation": "create", "outcomes": []any{"Yes", "No"}}, true},
+		"create one outcome":  {map[string]any{"operation": "create", "title": "Boss?", "outcomes": []any{"Yes"}}, true},
+		"create short window": {map[string]any{"operation": "create", "title": "Boss?", "outcomes": []any{"Yes", "No"}, "duration": "10s"}, true},
+		"create long window":  {map[string]any{"operation": "create", "title": "Boss?", "outcomes": []any{"Yes", "No"}, "duration": "1h"}, true},
+		"lock":                {map[string]any{"operation": "lock"}, false},
+		"cancel":              {map[string]any{"operation": "cancel"}, false},
+		"resolve":             {map[string]any{"operation": "resolve", "winning_outcome": "1"}, false},
+		"resolve no winner":   {map[string]any{"operation": "resolve"}, true},
+		"unknown operation":   {map[string]any{"operation": "start"}, true},
+		"unknown field":       {map[string]any{"operation": "lock", "foo": "bar"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
+
+func TestFindOutcomeID(t *testing.T) {
+	prediction := &twitch.PredictionInfo{Outcomes: []twitch.PredictionOutcome{
+		{ID: "o1", Title: "Yes"},
+		{ID: "o2", Title: "No"},
+		{ID: "o3", Title: "2"},
+	}}
+
+	for winner, expID := range map[string]string{
+		"yes": "o1",
+		"No":  "o2",
+		"1":   "o1",
+		"2":   "o3", // Title matches before index
+		"3":   "o3",
+	} {
+		id, err := findOutcomeID(prediction, winner)
+		require.NoError(t, err, winner)
+		assert.Equal(t, expID, id, winner)
+	}
+
+	for _, winner := range []string{"maybe", "0", "4"} {
+		_, err := findOutcomeID(prediction, winner)
+		assert.Error(t, err, winner)
+	}
+}
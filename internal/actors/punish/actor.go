@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,6 +26,10 @@ const (
 	actorNameResetPunish = "reset-punish"
 
 	oneWeek = 168 * time.Hour
+
+	// punishmentRetention defines how long punishments are remembered
+	// for punishedWithin after their level fully cooled down
+	punishmentRetention = 90 * 24 * time.Hour
 )
 
 type (
@@ -35,6 +40,9 @@ type (
 		LastLevel int           `json:"last_level"`
 		Executed  time.Time     `json:"executed"`
 		Cooldown  time.Duration `json:"cooldown"`
+		// LastExecuted is the time of the last punishment and in
+		// contrast to Executed not moved forward by the cooldown
+		LastExecuted time.Time `json:"last_executed"`
 	}
 )
 
@@ -78,7 +86,7 @@ func Register(args plugins.RegistrationArguments) error {
 			},
 			{
 				Default:         "",
-				Description:     "Actions for each punishment level (ban, delete, duration-value i.e. 1m)",
+				Description:     "Actions for each punishment level (ban, delete, warn, duration-value i.e. 1m)",
 				Key:             "levels",
 				Name:            "Levels",
 				Optional:        false,
@@ -87,7 +95,7 @@ func Register(args plugins.RegistrationArguments) error {
 			},
 			{
 				Default:         "",
-				Description:     "Reason why the user was banned / timeouted",
+				Description:     "Reason why the user was banned / timeouted / warned (required when using warn level)",
 				Key:             "reason",
 				Name:            "Reason",
 				Optional:        true,
@@ -142,6 +150,45 @@ func Register(args plugins.RegistrationArguments) error {
 		},
 	})
 
+	args.RegisterTemplateFunction("punishedWithin", func(_ *irc.Message, _ *plugins.Rule, fields *fieldcollection.FieldCollection) any {
+		return func(user, duration string, uuid ...string) (bool, error) {
+			channel, err := fields.String("channel")
+			if err != nil {
+				return false, fmt.Errorf("channel not available: %w", err)
+			}
+
+			d, err := time.ParseDuration(duration)
+			if err != nil {
+				return false, fmt.Errorf("parsing duration: %w", err)
+			}
+
+			var id string
+			if len(uuid) > 0 {
+				id = uuid[0]
+			}
+
+			lvl, err := getPunishment(db, channel, user, id)
+			if err != nil {
+				return false, fmt.Errorf("getting stored punishment: %w", err)
+			}
+
+			lastExecuted := lvl.LastExecuted
+			if lastExecuted.IsZero() {
+				// Punishment was stored before LastExecuted was tracked
+				lastExecuted = lvl.Executed
+			}
+
+			return !lastExecuted.IsZero() && time.Since(lastExecuted) < d, nil
+		}
+	}, plugins.TemplateFuncDocumentation{
+		Description: "Returns whether the user received a punishment through the `punish` actor in the current channel within the given duration (user and uuid must match the ones given to the actor, punishments are remembered for 90 days)",
+		Syntax:      "punishedWithin <user> <duration> [uuid]",
+		Example: &plugins.TemplateFuncDocumentationExample{
+			Template:    `{{ punishedWithin .username "168h" }}`,
+			FakedOutput: "true",
+		},
+	})
+
 	return nil
 }
 
@@ -196,6 +243,16 @@ func (actorPunish) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, event
 			return false, fmt.Errorf("deleting message: %w", err)
 		}
 
+	case "warn":
+		if err = botTwitchClient().WarnUser(
+			context.Background(),
+			plugins.DeriveChannel(m, eventData),
+			strings.TrimLeft(user, "@"),
+			reason,
+		); err != nil {
+			return false, fmt.Errorf("executing user warn: %w", err)
+		}
+
 	default:
 		to, err := time.ParseDuration(lt)
 		if err != nil {
@@ -215,6 +272,7 @@ func (actorPunish) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, event
 
 	lvl.Cooldown = cooldown
 	lvl.Executed = time.Now().UTC()
+	lvl.LastExecuted = lvl.Executed
 	lvl.LastLevel = nLvl
 
 	if err = setPunishment(db, plugins.DeriveChannel(m, eventData), user, uuid, lvl); err != nil {
@@ -240,6 +298,10 @@ func (actorPunish) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *f
 		return fmt.Errorf("validating attributes: %w", err)
 	}
 
+	if slices.Contains(attrs.MustStringSlice("levels", nil), "warn") && attrs.MustString("reason", new("")) == "" {
+		return errors.New("reason is required when using warn level")
+	}
+
 	return nil
 }
 
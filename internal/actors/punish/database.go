@@ -18,9 +18,10 @@ type (
 	punishLevel struct {
 		Key string `gorm:"primaryKey"`
 
-		LastLevel int
-		Executed  time.Time
-		Cooldown  time.Duration
+		LastLevel    int
+		Executed     time.Time
+		Cooldown     time.Duration
+		LastExecuted time.Time
 	}
 )
 
@@ -34,13 +35,14 @@ func calculateCurrentPunishments(db database.Connector) (err error) {
 		var (
 			actUpdate bool
 			lvl       = &levelConfig{
-				LastLevel: p.LastLevel,
-				Executed:  p.Executed,
-				Cooldown:  p.Cooldown,
+				LastLevel:    p.LastLevel,
+				Executed:     p.Executed,
+				Cooldown:     p.Cooldown,
+				LastExecuted: p.LastExecuted,
 			}
 		)
 
-		for {
+		for lvl.LastLevel >= 0 {
 			cooldownTime := lvl.Executed.Add(lvl.Cooldown)
 			if cooldownTime.After(time.Now().UTC()) {
 				break
@@ -51,8 +53,16 @@ func calculateCurrentPunishments(db database.Connector) (err error) {
 			actUpdate = true
 		}
 
-		// Level 0 is the first punishment level, so only remove if it drops below 0
-		if lvl.LastLevel < 0 {
+		lastExecuted := lvl.LastExecuted
+		if lastExecuted.IsZero() {
+			// Punishment was stored before LastExecuted was tracked
+			lastExecuted = lvl.Executed
+		}
+
+		// Level 0 is the first punishment level, so only remove if it
+		// drops below 0 and is no longer needed to know the user was
+		// punished recently
+		if lvl.LastLevel < 0 && time.Since(lastExecuted) > punishmentRetention {
 			if err = deletePunishmentForKey(db, p.Key); err != nil {
 				return fmt.Errorf("cleaning up expired punishment: %w", err)
 			}
@@ -103,9 +113,10 @@ func getPunishment(db database.Connector, channel, user, uuid string) (*levelCon
 	switch {
 	case err == nil:
 		return &levelConfig{
-			LastLevel: p.LastLevel,
-			Executed:  p.Executed,
-			Cooldown:  p.Cooldown,
+			LastLevel:    p.LastLevel,
+			Executed:     p.Executed,
+			Cooldown:     p.Cooldown,
+			LastExecuted: p.LastExecuted,
 		}, nil
 
 	case errors.Is(err, gorm.ErrRecordNotFound):
@@ -130,10 +141,11 @@ func setPunishmentForKey(db database.Connector, key string, lc *levelConfig) err
 			Columns:   []clause.Column{{Name: "key"}},
 			UpdateAll: true,
 		}).Create(punishLevel{
-			Key:       key,
-			LastLevel: lc.LastLevel,
-			Executed:  lc.Executed,
-			Cooldown:  lc.Cooldown,
+			Key:          key,
+			LastLevel:    lc.LastLevel,
+			Executed:     lc.Executed,
+			Cooldown:     lc.Cooldown,
+			LastExecuted: lc.LastExecuted,
 		}).Error
 	}); err != nil {
 		return fmt.Errorf("updating punishment info: %w", err)
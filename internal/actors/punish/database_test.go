@@ -26,10 +26,13 @@ func TestPunishmentRoundtrip(t *testing.T) {
 	assert.Zero(t, pl.Executed, "check default time")
 	assert.Zero(t, pl.Cooldown, "check default cooldown")
 
+	executed := time.Now().UTC()
+
 	err = setPunishment(dbc, channel, user, uuid, &levelConfig{
-		Cooldown:  500 * time.Millisecond,
-		Executed:  time.Now().UTC(),
-		LastLevel: 1,
+		Cooldown:     500 * time.Millisecond,
+		Executed:     executed,
+		LastExecuted: executed,
+		LastLevel:    1,
 	})
 	require.NoError(t, err, "setting punishment")
 
@@ -44,12 +47,32 @@ func TestPunishmentRoundtrip(t *testing.T) {
 	assert.Equal(t, 0, pl.LastLevel, "check level after one cooldown")
 	assert.NotZero(t, pl.Executed, "check non-zero-time after one cooldown")
 	assert.Equal(t, 500*time.Millisecond, pl.Cooldown, "check non-zero-cooldown after one cooldown")
+	assert.WithinDuration(t, executed, pl.LastExecuted, time.Millisecond, "check last execution is kept after one cooldown")
 
 	time.Sleep(500 * time.Millisecond) // Wait for one cooldown to happen
 
 	pl, err = getPunishment(dbc, channel, user, uuid)
 	require.NoError(t, err, "query existent punishment")
 	assert.Equal(t, -1, pl.LastLevel, "check level after two cooldown")
-	assert.Zero(t, pl.Executed, "check zero-time after two cooldown")
-	assert.Zero(t, pl.Cooldown, "check zero-cooldown after two cooldown")
+	assert.WithinDuration(t, executed, pl.LastExecuted, time.Millisecond, "check last execution is kept after two cooldown")
+
+	time.Sleep(500 * time.Millisecond) // Wait for another cooldown to pass
+
+	pl, err = getPunishment(dbc, channel, user, uuid)
+	require.NoError(t, err, "query existent punishment")
+	assert.Equal(t, -1, pl.LastLevel, "check level does not drop further")
+
+	require.NoError(t, setPunishment(dbc, channel, user, uuid, &levelConfig{
+		Cooldown:     500 * time.Millisecond,
+		Executed:     executed.Add(-punishmentRetention),
+		LastExecuted: executed.Add(-punishmentRetention),
+		LastLevel:    0,
+	}), "setting outdated punishment")
+
+	pl, err = getPunishment(dbc, channel, user, uuid)
+	require.NoError(t, err, "query outdated punishment")
+	assert.Equal(t, -1, pl.LastLevel, "check level of outdated punishment")
+	assert.Zero(t, pl.LastExecuted, "check outdated punishment is removed")
+	assert.Zero(t, pl.Executed, "check zero-time of removed punishment")
+	assert.Zero(t, pl.Cooldown, "check zero-cooldown of removed punishment")
 }
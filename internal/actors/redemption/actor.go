@@ -0,0 +1,146 @@
+// Package redemption contains an actor to fulfill or cancel (refund)
+// channel-point redemptions
+package redemption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actorName = "redemption"
+
+	statusCancel  = "cancel"
+	statusFulfill = "fulfill"
+)
+
+type actor struct{}
+
+var (
+	formatMessage plugins.MsgFormatter
+	permCheckFn   plugins.ChannelPermissionCheckFunc
+	tcGetter      func(string) (*twitch.Client, error)
+
+	ptrStringEmpty = new("")
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	formatMessage = args.FormatMessage
+	permCheckFn = args.HasPermissionForChannel
+	tcGetter = args.GetTwitchClientForChannel
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Fulfill or cancel (refund) a channel-point redemption (only works for rewards created through the bot)",
+		Name:        "Update Redemption Status",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "New status of the redemption: `fulfill` or `cancel` (refunds the points)",
+				Key:             "status",
+				Name:            "Status",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "ID of the reward (defaults to `reward_id` of the `channelpoint_redeem` event)",
+				Key:             "reward_id",
+				Name:            "Reward ID",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "ID of the redemption (defaults to `redemption_id` of the `channelpoint_redeem` event)",
+				Key:             "redemption_id",
+				Name:            "Redemption ID",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	channel := strings.TrimLeft(plugins.DeriveChannel(m, eventData), "#")
+
+	ids := map[string]string{}
+	for _, field := range []string{"redemption_id", "reward_id"} {
+		if ids[field], err = formatMessage(attrs.MustString(field, ptrStringEmpty), m, r, eventData); err != nil {
+			return false, fmt.Errorf("executing %s template: %w", field, err)
+		}
+
+		if ids[field] == "" {
+			ids[field] = eventData.MustString(field, ptrStringEmpty)
+		}
+
+		if ids[field] == "" {
+			return false, fmt.Errorf("no %s available", field)
+		}
+	}
+
+	status := twitch.RedemptionStatusFulfilled
+	if attrs.MustString("status", nil) == statusCancel {
+		status = twitch.RedemptionStatusCanceled
+	}
+
+	ok, err := permCheckFn(channel, twitch.ScopeChannelManageRedemptions)
+	if err != nil {
+		return false, fmt.Errorf("checking for channel permissions: %w", err)
+	}
+
+	if !ok {
+		return false, fmt.Errorf("channel %q is missing permission %s", channel, twitch.ScopeChannelManageRedemptions)
+	}
+
+	tc, err := tcGetter(channel)
+	if err != nil {
+		return false, fmt.Errorf("getting channel twitch-client: %w", err)
+	}
+
+	if err = tc.UpdateRedemptionStatus(context.Background(), channel, ids["reward_id"], ids["redemption_id"], status); err != nil {
+		return false, fmt.Errorf("updating redemption status: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "status", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "reward_id", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "redemption_id", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "reward_id", "redemption_id"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	if s := attrs.MustString("status", nil); s != statusCancel && s != statusFulfill {
+		return errors.New("status must be one of fulfill or cancel")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package redemption
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, every user has the ID 123 and redemption requests are
+// answered by the given handler
+func setupTestActor(t *testing.T, redemptions http.HandlerFunc) {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"testchannel"}]}`)
+
+		default:
+			redemptions(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+	permCheckFn = func(string, ...string) (bool, error) { return true, nil }
+	tcGetter = func(string) (*twitch.Client, error) { return tc, nil }
+}
+
+func TestExecute(t *testing.T) {
+	eventData := map[string]any{"channel": "#testchannel", "redemption_id": "evtred", "reward_id": "evtrew"}
+
+	for name, tc := range map[string]struct {
+		attrs     map[string]any
+		eventData map[string]any
+		expErr    bool
+		expQuery  url.Values
+		expStatus string
+	}{
+		"fulfill from event": {
+			attrs:     map[string]any{"status": "fulfill"},
+			eventData: eventData,
+			expQuery:  url.Values{"broadcaster_id": {"123"}, "id": {"evtred"}, "reward_id": {"evtrew"}},
+			expStatus: twitch.RedemptionStatusFulfilled,
+		},
+		"cancel given redemption": {
+			attrs:     map[string]any{"status": "cancel", "redemption_id": "red", "reward_id": "rew"},
+			eventData: eventData,
+			expQuery:  url.Values{"broadcaster_id": {"123"}, "id": {"red"}, "reward_id": {"rew"}},
+			expStatus: twitch.RedemptionStatusCanceled,
+		},
+		"missing redemption": {
+			attrs:     map[string]any{"status": "fulfill", "reward_id": "rew"},
+			eventData: map[string]any{"channel": "#testchannel"},
+			expErr:    true,
+		},
+	} {
+		var (
+			reqQuery url.Values
+			reqBody  struct {
+				Status string `json:"status"`
+			}
+		)
+
+		setupTestActor(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/helix/channel_points/custom_rewards/redemptions", r.URL.Path)
+			assert.Equal(t, http.MethodPatch, r.Method)
+
+			reqQuery = r.URL.Query()
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+
+			_, err := w.Write([]byte(`{"data":[]}`))
+			assert.NoError(t, err)
+		})
+
+		_, err := actor{}.Execute(nil, nil, nil, fieldcollection.FromData(tc.eventData), fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+			assert.Nil(t, reqQuery, name)
+			continue
+		}
+
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expQuery, reqQuery, name)
+		assert.Equal(t, tc.expStatus, reqBody.Status, name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"fulfill":        {map[string]any{"status": "fulfill"}, false},
+		"cancel":         {map[string]any{"status": "cancel", "reward_id": "{{ .reward_id }}"}, false},
+		"unknown status": {map[string]any{"status": "refund"}, true},
+		"no status":      {map[string]any{"reward_id": "rew"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package reward contains an actor to create and modify channel-point
+// custom rewards
+package reward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actorName = "reward"
+
+	operationCreate  = "create"
+	operationDisable = "disable"
+	operationEnable  = "enable"
+	operationPause   = "pause"
+	operationResume  = "resume"
+	operationUpdate  = "update"
+)
+
+type actor struct{}
+
+var (
+	formatMessage plugins.MsgFormatter
+	permCheckFn   plugins.ChannelPermissionCheckFunc
+	tcGetter      func(string) (*twitch.Client, error)
+
+	ptrStringEmpty = new("")
+)
+
+// Register provides the plugins.RegisterFunc
+//
+//nolint:funlen // just the documentation
+func Register(args plugins.RegistrationArguments) error {
+	formatMessage = args.FormatMessage
+	permCheckFn = args.HasPermissionForChannel
+	tcGetter = args.GetTwitchClientForChannel
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Create, update, enable, disable, pause or resume a channel-point reward in the current channel (only rewards created through the bot can be modified)",
+		Name:        "Manage Channel-Point Reward",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "What to do with the reward: `create`, `update`, `enable`, `disable`, `pause` or `resume`",
+				Key:             "operation",
+				Name:            "Operation",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "ID or title of the reward to modify (required for all operations except `create`)",
+				Key:             "reward",
+				Name:            "Reward",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Title of the reward (required for `create`)",
+				Key:             "title",
+				Name:            "Title",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Description shown to the viewer",
+				Key:             "prompt",
+				Name:            "Prompt",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Cost of the reward in channel points (required for `create`)",
+				Key:             "cost",
+				Name:            "Cost",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeInt64,
+			},
+			{
+				Default:         "",
+				Description:     "Background color of the reward (Hex format like `#9147FF`)",
+				Key:             "background_color",
+				Name:            "Background Color",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "false",
+				Description:     "Whether the viewer needs to enter text when redeeming the reward",
+				Key:             "user_input_required",
+				Name:            "User Input Required",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         "false",
+				Description:     "Whether redemptions are set to fulfilled immediately (skipping the request queue)",
+				Key:             "skip_request_queue",
+				Name:            "Skip Request Queue",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeBool,
+			},
+			{
+				Default:         "0",
+				Description:     "Maximum number of redemptions per stream (`0` = unlimited)",
+				Key:             "max_per_stream",
+				Name:            "Max per Stream",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeInt64,
+			},
+			{
+				Default:         "0",
+				Description:     "Maximum number of redemptions per user per stream (`0` = unlimited)",
+				Key:             "max_per_user_per_stream",
+				Name:            "Max per User per Stream",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeInt64,
+			},
+			{
+				Default:         "0s",
+				Description:     "Cooldown between two redemptions (`0s` = no cooldown)",
+				Key:             "global_cooldown",
+				Name:            "Global Cooldown",
+				Optional:        true,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeDuration,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	channel := strings.TrimLeft(plugins.DeriveChannel(m, eventData), "#")
+
+	ok, err := permCheckFn(channel, twitch.ScopeChannelManageRedemptions)
+	if err != nil {
+		return false, fmt.Errorf("checking for channel permissions: %w", err)
+	}
+
+	if !ok {
+		return false, fmt.Errorf("channel %q is missing permission %s", channel, twitch.ScopeChannelManageRedemptions)
+	}
+
+	tc, err := tcGetter(channel)
+	if err != nil {
+		return false, fmt.Errorf("getting channel twitch-client: %w", err)
+	}
+
+	var update twitch.CustomRewardUpdate
+
+	operation := attrs.MustString("operation", nil)
+	switch operation {
+	case operationCreate, operationUpdate:
+		if update, err = rewardUpdateFromAttributes(m, r, eventData, attrs); err != nil {
+			return false, err
+		}
+
+	case operationDisable, operationEnable:
+		update.IsEnabled = new(operation == operationEnable)
+
+	case operationPause, operationResume:
+		update.IsPaused = new(operation == operationPause)
+
+	default:
+		return false, fmt.Errorf("unknown operation %q", operation)
+	}
+
+	if operation == operationCreate {
+		if _, err = tc.CreateCustomReward(context.Background(), channel, update); err != nil {
+			return false, fmt.Errorf("creating reward: %w", err)
+		}
+
+		return false, nil
+	}
+
+	rewardRef, err := formatMessage(attrs.MustString("reward", ptrStringEmpty), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing reward template: %w", err)
+	}
+
+	reward, err := tc.GetCustomRewardByIDOrTitle(context.Background(), channel, rewardRef)
+	if err != nil {
+		return false, fmt.Errorf("finding reward: %w", err)
+	}
+
+	if _, err = tc.UpdateCustomReward(context.Background(), channel, reward.ID, update); err != nil {
+		return false, fmt.Errorf("updating reward: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "operation", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "reward", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "title", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "prompt", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "cost", Type: fieldcollection.SchemaFieldTypeInt64}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "background_color", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "user_input_required", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "skip_request_queue", Type: fieldcollection.SchemaFieldTypeBool}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "max_per_stream", Type: fieldcollection.SchemaFieldTypeInt64}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "max_per_user_per_stream", Type: fieldcollection.SchemaFieldTypeInt64}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "global_cooldown", Type: fieldcollection.SchemaFieldTypeDuration}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "reward", "title", "prompt"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	switch attrs.MustString("operation", nil) {
+	case operationCreate:
+		if attrs.MustString("title", ptrStringEmpty) == "" {
+			return errors.New("title is required for create")
+		}
+
+		if attrs.MustInt64("cost", new(int64(0))) < 1 {
+			return errors.New("cost must be positive for create")
+		}
+
+	case operationDisable, operationEnable, operationPause, operationResume, operationUpdate:
+		if attrs.MustString("reward", ptrStringEmpty) == "" {
+			return errors.New("reward is required")
+		}
+
+	default:
+		return fmt.Errorf("unknown operation %q", attrs.MustString("operation", nil))
+	}
+
+	return nil
+}
+
+// rewardUpdateFromAttributes creates an update containing only the
+// fields set in the attributes
+func rewardUpdateFromAttributes(
+	m *irc.Message,
+	r *plugins.Rule,
+	eventData *fieldcollection.FieldCollection,
+	attrs *fieldcollection.FieldCollection,
+) (update twitch.CustomRewardUpdate, err error) {
+	for field, target := range map[string]**string{
+		"prompt": &update.Prompt,
+		"title":  &update.Title,
+	} {
+		if !attrs.HasAll(field) {
+			continue
+		}
+
+		v, err := formatMessage(attrs.MustString(field, nil), m, r, eventData)
+		if err != nil {
+			return update, fmt.Errorf("executing %s template: %w", field, err)
+		}
+		*target = &v
+	}
+
+	if attrs.HasAll("background_color") {
+		update.BackgroundColor = new(attrs.MustString("background_color", nil))
+	}
+
+	if attrs.HasAll("cost") {
+		update.Cost = new(attrs.MustInt64("cost", nil))
+	}
+
+	if attrs.HasAll("global_cooldown") {
+		cooldown := attrs.MustDuration("global_cooldown", nil)
+		update.IsGlobalCooldownEnabled = new(cooldown > 0)
+		if cooldown > 0 {
+			update.GlobalCooldownSeconds = new(int64(cooldown / time.Second))
+		}
+	}
+
+	if attrs.HasAll("max_per_stream") {
+		limit := attrs.MustInt64("max_per_stream", nil)
+		update.IsMaxPerStreamEnabled = new(limit > 0)
+		if limit > 0 {
+			update.MaxPerStream = &limit
+		}
+	}
+
+	if attrs.HasAll("max_per_user_per_stream") {
+		limit := attrs.MustInt64("max_per_user_per_stream", nil)
+		update.IsMaxPerUserPerStreamEnabled = new(limit > 0)
+		if limit > 0 {
+			update.MaxPerUserPerStream = &limit
+		}
+	}
+
+	if attrs.HasAll("skip_request_queue") {
+		update.ShouldRedemptionsSkipRequestQueue = new(attrs.MustBool("skip_request_queue", nil))
+	}
+
+	if attrs.HasAll("user_input_required") {
+		update.IsUserInputRequired = new(attrs.MustBool("user_input_required", nil))
+	}
+
+	return update, nil
+}
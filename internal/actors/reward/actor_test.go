@@ -0,0 +1,194 @@
+package reward
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, every user has the ID 123 and reward requests are
+// answered by the given handler
+func setupTestActor(t *testing.T, rewards http.HandlerFunc) {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"testchannel"}]}`)
+
+		default:
+			rewards(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+	permCheckFn = func(string, ...string) (bool, error) { return true, nil }
+	tcGetter = func(string) (*twitch.Client, error) { return tc, nil }
+}
+
+func TestExecute(t *testing.T) {
+	type rewardRequest struct {
+		method, rewardID, body string
+	}
+
+	for name, tc := range map[string]struct {
+		attrs   map[string]any
+		expErr  bool
+		expReqs []rewardRequest
+	}{
+		"create": {
+			attrs:   map[string]any{"operation": "create", "title": "Hydrate", "cost": 100},
+			expReqs: []rewardRequest{{http.MethodPost, "", `{"title":"Hydrate","cost":100}`}},
+		},
+		"update by title": {
+			attrs: map[string]any{"operation": "update", "reward": "stretch", "cost": 50},
+			expReqs: []rewardRequest{
+				{http.MethodGet, "", ""},
+				{http.MethodPatch, "r2", `{"cost":50}`},
+			},
+		},
+		"disable": {
+			attrs: map[string]any{"operation": "disable", "reward": "r1"},
+			expReqs: []rewardRequest{
+				{http.MethodGet, "", ""},
+				{http.MethodPatch, "r1", `{"is_enabled":false}`},
+			},
+		},
+		"pause": {
+			attrs: map[string]any{"operation": "pause", "reward": "Hydrate"},
+			expReqs: []rewardRequest{
+				{http.MethodGet, "", ""},
+				{http.MethodPatch, "r1", `{"is_paused":true}`},
+			},
+		},
+		"unknown reward": {
+			attrs:   map[string]any{"operation": "resume", "reward": "r3"},
+			expErr:  true,
+			expReqs: []rewardRequest{{http.MethodGet, "", ""}},
+		},
+	} {
+		var reqs []rewardRequest
+		setupTestActor(t, func(w http.ResponseWriter, r *http.Request) {
+			req := rewardRequest{method: r.Method, rewardID: r.URL.Query().Get("id")}
+			if r.Body != nil {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				req.body = string(body)
+			}
+			reqs = append(reqs, req)
+
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"id": "r1", "title": "Hydrate"}, {"id": "r2", "title": "Stretch"}},
+			}))
+		})
+
+		_, err := actor{}.Execute(nil, nil, nil, fieldcollection.FromData(map[string]any{"channel": "#testchannel"}), fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+
+		require.Len(t, reqs, len(tc.expReqs), name)
+		for i, exp := range tc.expReqs {
+			assert.Equal(t, exp.method, reqs[i].method, name)
+			assert.Equal(t, exp.rewardID, reqs[i].rewardID, name)
+			if exp.body != "" {
+				assert.JSONEq(t, exp.body, reqs[i].body, name)
+			}
+		}
+	}
+}
+
+func TestRewardUpdateFromAttributes(t *testing.T) {
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+
+	for name, tc := range map[string]struct {
+		attrs   map[string]any
+		expBody string
+	}{
+		"nothing set": {map[string]any{}, `{}`},
+		"texts": {
+			map[string]any{"title": "Hydrate", "prompt": "Drink!", "background_color": "#00FF00"},
+			`{"title":"Hydrate","prompt":"Drink!","background_color":"#00FF00"}`,
+		},
+		"limits enabled": {
+			map[string]any{"max_per_stream": 5, "max_per_user_per_stream": 1, "global_cooldown": "90s"},
+			`{"is_max_per_stream_enabled":true,"max_per_stream":5,"is_max_per_user_per_stream_enabled":true,"max_per_user_per_stream":1,"is_global_cooldown_enabled":true,"global_cooldown_seconds":90}`,
+		},
+		"limits disabled": {
+			map[string]any{"max_per_stream": 0, "max_per_user_per_stream": 0, "global_cooldown": "0s"},
+			`{"is_max_per_stream_enabled":false,"is_max_per_user_per_stream_enabled":false,"is_global_cooldown_enabled":false}`,
+		},
+		"flags": {
+			map[string]any{"user_input_required": false, "skip_request_queue": true, "cost": 10},
+			`{"cost":10,"is_user_input_required":false,"should_redemptions_skip_request_queue":true}`,
+		},
+	} {
+		update, err := rewardUpdateFromAttributes(nil, nil, nil, fieldcollection.FromData(tc.attrs))
+		require.NoError(t, err, name)
+
+		body, err := json.Marshal(update)
+		require.NoError(t, err, name)
+		assert.JSONEq(t, tc.expBody, string(body), name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"create":            {map[string]any{"operation": "create", "title": "Hydrate", "cost": 100}, false},
+		"create no title":   {map[string]any{"operation": "create", "cost": 100}, true},
+		"create no cost":    {map[string]any{"operation": "create", "title": "Hydrate"}, true},
+		"update":            {map[string]any{"operation": "update", "reward": "Hydrate", "cost": 50}, false},
+		"enable no reward":  {map[string]any{"operation": "enable"}, true},
+		"pause":             {map[string]any{"operation": "pause", "reward": "r1"}, false},
+		"unknown operation": {map[string]any{"operation": "delete", "reward": "r1"}, true},
+		"unknown field":     {map[string]any{"operation": "pause", "reward": "r1", "foo": "bar"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
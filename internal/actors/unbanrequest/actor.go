@@ -0,0 +1,131 @@
+// Package unbanrequest contains an actor to approve or deny unban
+// requests
+package unbanrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	actionApprove = "approve"
+	actionDeny    = "deny"
+
+	actorName = "unbanrequest"
+)
+
+type actor struct{}
+
+var (
+	botTwitchClient func() *twitch.Client
+	formatMessage   plugins.MsgFormatter
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	botTwitchClient = args.GetTwitchClient
+	formatMessage = args.FormatMessage
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Approve or deny an unban request (requires the bot to be moderator with moderator:manage:unban_requests scope)",
+		Name:        "Resolve Unban Request",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Action to execute (one of: approve, deny)",
+				Key:             "action",
+				Name:            "Action",
+				Optional:        false,
+				SupportTemplate: false,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "Resolution message shown to the user",
+				Key:             "message",
+				Name:            "Message",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+			{
+				Default:         "",
+				Description:     "ID of the unban request (defaults to the `request_id` of the `unban_request` event)",
+				Key:             "request_id",
+				Name:            "Request ID",
+				Optional:        true,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	requestID, err := formatMessage(attrs.MustString("request_id", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing request_id template: %w", err)
+	}
+
+	if requestID == "" {
+		requestID = eventData.MustString("request_id", new(""))
+	}
+
+	if requestID == "" {
+		return false, errors.New("no request_id available")
+	}
+
+	message, err := formatMessage(attrs.MustString("message", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing message template: %w", err)
+	}
+
+	if err = botTwitchClient().ResolveUnbanRequest(
+		context.Background(),
+		plugins.DeriveChannel(m, eventData),
+		requestID,
+		attrs.MustString("action", new("")) == actionApprove,
+		message,
+	); err != nil {
+		return false, fmt.Errorf("resolving unban request: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "action", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "message", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.CanHaveField(fieldcollection.SchemaField{Name: "request_id", Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "message", "request_id"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	switch action := attrs.MustString("action", new("")); action {
+	case actionApprove, actionDeny:
+		return nil
+
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+}
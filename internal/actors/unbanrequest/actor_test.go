@@ -0,0 +1,128 @@
+package unbanrequest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, every user has the ID 123 and unban requests are
+// answered by the given handler
+func setupTestActor(t *testing.T, unbanRequests http.HandlerFunc) {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"testchannel"}]}`)
+
+		default:
+			unbanRequests(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	botTwitchClient = func() *twitch.Client { return tc }
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+}
+
+func TestExecute(t *testing.T) {
+	eventData := map[string]any{"channel": "#testchannel", "request_id": "evtreq"}
+
+	for name, tc := range map[string]struct {
+		attrs     map[string]any
+		eventData map[string]any
+		expErr    bool
+		expQuery  url.Values
+	}{
+		"approve from event": {
+			attrs:     map[string]any{"action": "approve", "message": "welcome back"},
+			eventData: eventData,
+			expQuery: url.Values{
+				"broadcaster_id": {"123"}, "moderator_id": {"123"}, "unban_request_id": {"evtreq"}, "status": {"approved"}, "resolution_text": {"welcome back"},
+			},
+		},
+		"deny given request": {
+			attrs:     map[string]any{"action": "deny", "request_id": "req"},
+			eventData: eventData,
+			expQuery: url.Values{
+				"broadcaster_id": {"123"}, "moderator_id": {"123"}, "unban_request_id": {"req"}, "status": {"denied"},
+			},
+		},
+		"no request": {
+			attrs:     map[string]any{"action": "deny"},
+			eventData: map[string]any{"channel": "#testchannel"},
+			expErr:    true,
+		},
+	} {
+		var reqQuery url.Values
+		setupTestActor(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/helix/moderation/unban_requests", r.URL.Path)
+			reqQuery = r.URL.Query()
+
+			_, err := w.Write([]byte(`{"data":[]}`))
+			assert.NoError(t, err)
+		})
+
+		_, err := actor{}.Execute(nil, nil, nil, fieldcollection.FromData(tc.eventData), fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+			assert.Nil(t, reqQuery, name)
+			continue
+		}
+
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expQuery, reqQuery, name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"approve":        {map[string]any{"action": "approve"}, false},
+		"deny":           {map[string]any{"action": "deny", "message": "no", "request_id": "{{ .request_id }}"}, false},
+		"unknown action": {map[string]any{"action": "ignore"}, true},
+		"no action":      {map[string]any{"request_id": "req"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package warn contains an actor to warn users
+package warn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const actorName = "warn"
+
+type actor struct{}
+
+var (
+	botTwitchClient func() *twitch.Client
+	formatMessage   plugins.MsgFormatter
+
+	warnChatcommandRegex = regexp.MustCompile(`^/warn +([^\s]+) +(.+)$`)
+)
+
+// Register provides the plugins.RegisterFunc
+func Register(args plugins.RegistrationArguments) error {
+	botTwitchClient = args.GetTwitchClient
+	formatMessage = args.FormatMessage
+
+	args.RegisterActor(actorName, func() plugins.Actor { return &actor{} })
+
+	args.RegisterActorDocumentation(plugins.ActionDocumentation{
+		Description: "Warn user in chat (user must acknowledge the warning before being able to chat again)",
+		Name:        "Warn User",
+		Type:        actorName,
+
+		Fields: []plugins.ActionDocumentationField{
+			{
+				Default:         "",
+				Description:     "Reason why the user was warned (shown to the user, max 500 characters)",
+				Key:             "reason",
+				Name:            "Reason",
+				Optional:        false,
+				SupportTemplate: true,
+				Type:            plugins.ActionDocumentationFieldTypeString,
+			},
+		},
+	})
+
+	args.RegisterMessageModFunc("/warn", handleChatCommand)
+
+	return nil
+}
+
+func (actor) Execute(_ *irc.Client, m *irc.Message, r *plugins.Rule, eventData *fieldcollection.FieldCollection, attrs *fieldcollection.FieldCollection) (preventCooldown bool, err error) {
+	reason, err := formatMessage(attrs.MustString("reason", new("")), m, r, eventData)
+	if err != nil {
+		return false, fmt.Errorf("executing reason template: %w", err)
+	}
+
+	if err = botTwitchClient().WarnUser(
+		context.Background(),
+		plugins.DeriveChannel(m, eventData),
+		plugins.DeriveUser(m, eventData),
+		reason,
+	); err != nil {
+		return false, fmt.Errorf("executing warn: %w", err)
+	}
+
+	return false, nil
+}
+
+func (actor) IsAsync() bool { return false }
+func (actor) Name() string  { return actorName }
+
+func (actor) Validate(tplValidator plugins.TemplateValidatorFunc, attrs *fieldcollection.FieldCollection) (err error) {
+	if err = attrs.ValidateSchema(
+		fieldcollection.MustHaveField(fieldcollection.SchemaField{Name: "reason", NonEmpty: true, Type: fieldcollection.SchemaFieldTypeString}),
+		fieldcollection.MustHaveNoUnknowFields,
+		helpers.SchemaValidateTemplateField(tplValidator, "reason"),
+	); err != nil {
+		return fmt.Errorf("validating attributes: %w", err)
+	}
+
+	return nil
+}
+
+func handleChatCommand(m *irc.Message) error {
+	channel := plugins.DeriveChannel(m, nil)
+
+	matches := warnChatcommandRegex.FindStringSubmatch(m.Trailing())
+	if matches == nil {
+		return errors.New("warn message does not match required format")
+	}
+
+	if err := botTwitchClient().WarnUser(context.Background(), channel, matches[1], matches[2]); err != nil {
+		return fmt.Errorf("executing warn: %w", err)
+	}
+
+	return plugins.ErrSkipSendingMessage
+}
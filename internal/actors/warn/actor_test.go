@@ -0,0 +1,143 @@
+package warn
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type (
+	testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+	testWarnRequest struct {
+		query url.Values
+		body  map[string]map[string]string
+	}
+)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// setupTestActor points the actor to a fake Twitch API: tokens are
+// always valid, every user has their login as ID (the bot "bot") and
+// the warn requests are collected
+func setupTestActor(t *testing.T) *[]testWarnRequest {
+	t.Helper()
+
+	var reqs []testWarnRequest
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case "/helix/users":
+			login := r.URL.Query().Get("login")
+			if login == "" {
+				login = "bot"
+			}
+			_, _ = resp.WriteString(`{"data":[{"id":"` + login + `","login":"` + login + `"}]}`)
+
+		case "/helix/moderation/warnings":
+			req := testWarnRequest{query: r.URL.Query()}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req.body))
+			reqs = append(reqs, req)
+			_, _ = resp.WriteString(`{"data":[]}`)
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	tc := twitch.New("id", "secret", "token", "")
+
+	botTwitchClient = func() *twitch.Client { return tc }
+	formatMessage = func(tplString string, _ *irc.Message, _ *plugins.Rule, _ *fieldcollection.FieldCollection) (string, error) {
+		return tplString, nil
+	}
+
+	return &reqs
+}
+
+func TestExecute(t *testing.T) {
+	reqs := setupTestActor(t)
+
+	_, err := actor{}.Execute(nil, nil, nil, fieldcollection.FromData(map[string]any{
+		"channel":  "#testchannel",
+		"username": "amy",
+	}), fieldcollection.FromData(map[string]any{"reason": "be nice"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []testWarnRequest{{
+		query: url.Values{"broadcaster_id": {"testchannel"}, "moderator_id": {"bot"}},
+		body:  map[string]map[string]string{"data": {"reason": "be nice", "user_id": "amy"}},
+	}}, *reqs)
+}
+
+func TestHandleChatCommand(t *testing.T) {
+	for msg, expReq := range map[string]*testWarnRequest{
+		"/warn amy stop spamming": {
+			query: url.Values{"broadcaster_id": {"testchannel"}, "moderator_id": {"bot"}},
+			body:  map[string]map[string]string{"data": {"reason": "stop spamming", "user_id": "amy"}},
+		},
+		"/warn @amy  be nice": {
+			query: url.Values{"broadcaster_id": {"testchannel"}, "moderator_id": {"bot"}},
+			body:  map[string]map[string]string{"data": {"reason": "be nice", "user_id": "amy"}},
+		},
+		"/warn amy": nil,
+		"/warn":     nil,
+	} {
+		reqs := setupTestActor(t)
+
+		err := handleChatCommand(&irc.Message{Command: "PRIVMSG", Params: []string{"#testchannel", msg}})
+		if expReq == nil {
+			assert.Error(t, err, msg)
+			assert.NotErrorIs(t, err, plugins.ErrSkipSendingMessage, msg)
+			assert.Empty(t, *reqs, msg)
+			continue
+		}
+
+		assert.ErrorIs(t, err, plugins.ErrSkipSendingMessage, msg)
+		assert.Equal(t, []testWarnRequest{*expReq}, *reqs, msg)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tplValidator := func(string) error { return nil }
+
+	for name, tc := range map[string]struct {
+		attrs  map[string]any
+		expErr bool
+	}{
+		"reason":        {map[string]any{"reason": "be nice"}, false},
+		"no reason":     {map[string]any{}, true},
+		"empty reason":  {map[string]any{"reason": ""}, true},
+		"unknown field": {map[string]any{"reason": "be nice", "user": "amy"}, true},
+	} {
+		err := actor{}.Validate(tplValidator, fieldcollection.FromData(tc.attrs))
+		if tc.expErr {
+			assert.Error(t, err, name)
+		} else {
+			assert.NoError(t, err, name)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package eventlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+type (
+	eventLogEntry struct {
+		ID        uint64    `gorm:"primaryKey"`
+		Channel   string    `gorm:"not null;index:eventlog_channel_idx"`
+		CreatedAt time.Time `gorm:"index:eventlog_channel_idx"`
+		EventType string    `gorm:"index"`
+		Username  string    `gorm:"index"`
+		Fields    string
+	}
+
+	// entry is the API / template representation of an eventLogEntry
+	entry struct {
+		ID      uint64                           `json:"id"`
+		Channel string                           `json:"channel"`
+		Fields  *fieldcollection.FieldCollection `json:"fields"`
+		Time    time.Time                        `json:"time"`
+		Type    string                           `json:"type"`
+		User    string                           `json:"user,omitempty"`
+	}
+
+	entryFilter struct {
+		Channel string
+		Limit   int
+		Offset  int
+		Since   time.Time
+		Type    string
+		Until   time.Time
+		User    string
+	}
+)
+
+func addEntry(db database.Connector, channel, eventType, user string, fields *fieldcollection.FieldCollection, t time.Time) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(fields); err != nil {
+		return fmt.Errorf("encoding fields: %w", err)
+	}
+
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		return tx.Create(&eventLogEntry{
+			Channel:   channel,
+			CreatedAt: t.UTC(),
+			EventType: eventType,
+			Username:  strings.ToLower(strings.TrimLeft(user, "@")),
+			Fields:    strings.TrimSpace(buf.String()),
+		}).Error
+	}); err != nil {
+		return fmt.Errorf("storing event to database: %w", err)
+	}
+
+	return nil
+}
+
+func cleanupChannel(db database.Connector, channel string, before time.Time) error {
+	if err := helpers.RetryTransaction(db.DB(), func(tx *gorm.DB) error {
+		return tx.
+			Where("channel = ? AND created_at < ?", channel, before.UTC()).
+			Delete(&eventLogEntry{}).
+			Error
+	}); err != nil {
+		return fmt.Errorf("deleting expired events: %w", err)
+	}
+
+	return nil
+}
+
+func getChannels(db database.Connector) (channels []string, err error) {
+	if err = helpers.Retry(func() error {
+		return db.DB().Model(&eventLogEntry{}).Distinct("channel").Pluck("channel", &channels).Error
+	}); err != nil {
+		return nil, fmt.Errorf("querying channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+func getEntries(db database.Connector, filter entryFilter) (entries []entry, total int64, err error) {
+	q := db.DB().Model(&eventLogEntry{})
+
+	for col, val := range map[string]string{
+		"channel":    filter.Channel,
+		"event_type": filter.Type,
+		"username":   strings.ToLower(strings.TrimLeft(filter.User, "@")),
+	} {
+		if val != "" {
+			q = q.Where(col+" = ?", val) //#nosec:G202 // Column names are static
+		}
+	}
+
+	if !filter.Since.IsZero() {
+		q = q.Where("created_at >= ?", filter.Since.UTC())
+	}
+
+	if !filter.Until.IsZero() {
+		q = q.Where("created_at < ?", filter.Until.UTC())
+	}
+
+	// Make the query reusable for counting and fetching
+	q = q.Session(&gorm.Session{})
+
+	var rawEntries []eventLogEntry
+
+	if err = helpers.Retry(func() error {
+		if err := q.Count(&total).Error; err != nil {
+			return fmt.Errorf("counting events: %w", err)
+		}
+
+		return q.
+			Order("created_at DESC").
+			Order("id DESC").
+			Limit(filter.Limit).
+			Offset(filter.Offset).
+			Find(&rawEntries).
+			Error
+	}); err != nil {
+		return nil, 0, fmt.Errorf("querying events: %w", err)
+	}
+
+	for _, e := range rawEntries {
+		ae, err := e.toEntry()
+		if err != nil {
+			return nil, 0, fmt.Errorf("transforming event %d: %w", e.ID, err)
+		}
+
+		entries = append(entries, ae)
+	}
+
+	return entries, total, nil
+}
+
+func getLastEntry(db database.Connector, channel, eventType string) (*entry, error) {
+	var e eventLogEntry
+
+	if err := helpers.Retry(func() error {
+		err := db.DB().
+			Where("channel = ? AND event_type = ?", channel, eventType).
+			Order("created_at DESC").
+			Order("id DESC").
+			First(&e).
+			Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("fetching event: %w", err)
+	}
+
+	if e.ID == 0 {
+		return nil, nil //nolint:nilnil // No event is no error
+	}
+
+	ae, err := e.toEntry()
+	if err != nil {
+		return nil, fmt.Errorf("transforming event: %w", err)
+	}
+
+	return &ae, nil
+}
+
+func (e eventLogEntry) toEntry() (entry, error) {
+	fields := new(fieldcollection.FieldCollection)
+	if err := json.NewDecoder(strings.NewReader(e.Fields)).Decode(fields); err != nil {
+		return entry{}, fmt.Errorf("decoding fields: %w", err)
+	}
+
+	return entry{
+		ID:      e.ID,
+		Channel: e.Channel,
+		Fields:  fields,
+		Time:    e.CreatedAt,
+		Type:    e.EventType,
+		User:    e.Username,
+	}, nil
+}
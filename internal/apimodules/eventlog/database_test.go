@@ -0,0 +1,75 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+)
+
+func TestEventLogStoreAndQuery(t *testing.T) {
+	dbc := database.GetTestDatabase(t)
+	require.NoError(t, dbc.DB().AutoMigrate(&eventLogEntry{}))
+
+	now := time.Now()
+
+	for i, e := range []struct {
+		channel, event, user string
+		age                  time.Duration
+	}{
+		{"#a", "follow", "@Alice", 3 * time.Hour},
+		{"#a", "raid", "bob", 2 * time.Hour},
+		{"#a", "follow", "carol", time.Hour},
+		{"#b", "follow", "dave", time.Hour},
+	} {
+		require.NoError(t, addEntry(dbc, e.channel, e.event, e.user, fieldcollection.FromData(map[string]any{
+			"channel": e.channel,
+			"idx":     i,
+			"user":    e.user,
+		}), now.Add(-e.age)))
+	}
+
+	last, err := getLastEntry(dbc, "#a", "follow")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, "carol", last.User)
+	assert.Equal(t, "carol", last.Fields.MustString("user", nil))
+
+	last, err = getLastEntry(dbc, "#b", "raid")
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	entries, total, err := getEntries(dbc, entryFilter{Channel: "#a", Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "carol", entries[0].User)
+
+	entries, total, err = getEntries(dbc, entryFilter{Limit: 10, User: "ALICE"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "follow", entries[0].Type)
+
+	entries, _, err = getEntries(dbc, entryFilter{Limit: 10, Since: now.Add(-150 * time.Minute), Type: "follow"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	channels, err := getChannels(dbc)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"#a", "#b"}, channels)
+
+	require.NoError(t, cleanupChannel(dbc, "#a", now.Add(-90*time.Minute)))
+
+	_, total, err = getEntries(dbc, entryFilter{Channel: "#a", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	_, total, err = getEntries(dbc, entryFilter{Channel: "#b", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
@@ -0,0 +1,264 @@
+// Package eventlog contains a bot-wide store of all events together
+// with an API and template functions to query them
+package eventlog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/irc.v4"
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	cleanupInterval  = time.Hour
+	defaultRetention = 30 * 24 * time.Hour
+	defaultLimit     = 100
+	maxLimit         = 1000
+	moduleName       = "eventlog"
+)
+
+var (
+	db              database.Connector
+	getModuleConfig plugins.ModuleConfigGetterFunc
+	logger          *logrus.Entry
+
+	defaultIgnoreEvents = []string{
+		"join", "part", // Those would flood the log without any value
+	}
+)
+
+// Register provides the plugins.RegisterFunc
+//
+//nolint:funlen // This function is a few lines too long but only contains definitions
+func Register(args plugins.RegistrationArguments) (err error) {
+	db = args.GetDatabaseConnector()
+	if err = db.DB().AutoMigrate(&eventLogEntry{}); err != nil {
+		return fmt.Errorf("applying schema migration: %w", err)
+	}
+
+	args.RegisterCopyDatabaseFunc("eventlog", func(src, target *gorm.DB) error {
+		return database.CopyObjects(src, target, &eventLogEntry{})
+	})
+
+	getModuleConfig = args.GetModuleConfigForChannel
+	logger = args.GetLogger(moduleName)
+
+	if err = args.RegisterEventHandler(handleEvent); err != nil {
+		return fmt.Errorf("registering event handler: %w", err)
+	}
+
+	if err = args.RegisterAPIRoute(plugins.HTTPRouteRegistrationArgs{
+		Description: "Searches the bot-wide event log, newest events first",
+		HandlerFunc: handleListEvents,
+		Method:      http.MethodGet,
+		Module:      "events",
+		Name:        "List events",
+		Path:        "/",
+		QueryParams: []plugins.HTTPRouteParamDocumentation{
+			{
+				Description: "Only return events of this channel",
+				Name:        "channel",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Description: fmt.Sprintf("Maximum number of events to return (default %d, max %d)", defaultLimit, maxLimit),
+				Name:        "limit",
+				Required:    false,
+				Type:        "int",
+			},
+			{
+				Description: "Number of events to skip for pagination",
+				Name:        "offset",
+				Required:    false,
+				Type:        "int",
+			},
+			{
+				Description: "ISO / RFC3339 timestamp to fetch the events at or after",
+				Name:        "since",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Description: "Only return events of this type",
+				Name:        "type",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Description: "ISO / RFC3339 timestamp to fetch the events before",
+				Name:        "until",
+				Required:    false,
+				Type:        "string",
+			},
+			{
+				Description: "Only return events of this user (login-name)",
+				Name:        "user",
+				Required:    false,
+				Type:        "string",
+			},
+		},
+		RequiresWriteAuth: true, // The log contains user data so it is handled as a write-module
+		ResponseType:      plugins.HTTPRouteResponseTypeJSON,
+	}); err != nil {
+		return fmt.Errorf("registering API route: %w", err)
+	}
+
+	args.RegisterTemplateFunction("lastEvent", func(_ *irc.Message, _ *plugins.Rule, fields *fieldcollection.FieldCollection) any {
+		return func(eventType string, channel ...string) (map[string]any, error) {
+			ch := fields.MustString("channel", new(""))
+			if len(channel) > 0 {
+				ch = channel[0]
+			}
+
+			if ch == "" {
+				return nil, errors.New("no channel available")
+			}
+
+			e, err := getLastEntry(db, "#"+strings.TrimLeft(ch, "#"), eventType)
+			if err != nil {
+				return nil, fmt.Errorf("getting last event: %w", err)
+			}
+
+			if e == nil {
+				return nil, nil //nolint:nilnil // No event is no error
+			}
+
+			return e.Fields.Data(), nil
+		}
+	}, plugins.TemplateFuncDocumentation{
+		Description: "Returns the fields of the last event of the given type in the current (or given) channel from the event log (empty if there is no such event)",
+		Syntax:      "lastEvent <event type> [channel]",
+		Example: &plugins.TemplateFuncDocumentationExample{
+			Template:    `{{ with lastEvent "raid" }}Last raider: {{ .from }}{{ end }}`,
+			FakedOutput: "Last raider: luziferus",
+		},
+	})
+
+	if _, err = args.RegisterCron(fmt.Sprintf("@every %s", cleanupInterval), scheduleCleanup); err != nil {
+		return fmt.Errorf("registering cleanup cron: %w", err)
+	}
+
+	return nil
+}
+
+func handleEvent(event string, eventData *fieldcollection.FieldCollection) error {
+	channel := plugins.DeriveChannel(nil, eventData)
+	if channel == "" {
+		// Events without channel cannot be attributed
+		return nil
+	}
+
+	if slices.Contains(getModuleConfig(moduleName, channel).MustStringSlice("ignore_events", &defaultIgnoreEvents), event) {
+		return nil
+	}
+
+	if eventData.MustBool("is_test", new(false)) {
+		// Injected test events must not show up as real events
+		return nil
+	}
+
+	user := eventData.MustString("user", new(""))
+	if user == "" {
+		user = eventData.MustString("username", new(""))
+	}
+
+	if err := addEntry(db, channel, event, user, eventData, time.Now()); err != nil {
+		return fmt.Errorf("adding event to log: %w", err)
+	}
+
+	return nil
+}
+
+func handleListEvents(w http.ResponseWriter, r *http.Request) {
+	filter := entryFilter{
+		Limit: defaultLimit,
+		Type:  r.FormValue("type"),
+		User:  r.FormValue("user"),
+	}
+
+	if ch := r.FormValue("channel"); ch != "" {
+		filter.Channel = "#" + strings.TrimLeft(ch, "#")
+	}
+
+	for param, target := range map[string]*int{
+		"limit":  &filter.Limit,
+		"offset": &filter.Offset,
+	} {
+		if v := r.FormValue(param); v != "" {
+			i, err := strconv.Atoi(v)
+			if err != nil || i < 0 {
+				http.Error(w, fmt.Sprintf("invalid %s", param), http.StatusBadRequest)
+				return
+			}
+			*target = i
+		}
+	}
+
+	filter.Limit = min(max(filter.Limit, 1), maxLimit)
+
+	for param, target := range map[string]*time.Time{
+		"since": &filter.Since,
+		"until": &filter.Until,
+	} {
+		if v := r.FormValue(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				http.Error(w, fmt.Errorf("parsing %s: %w", param, err).Error(), http.StatusBadRequest)
+				return
+			}
+			*target = t
+		}
+	}
+
+	events, total, err := getEntries(db, filter)
+	if err != nil {
+		http.Error(w, fmt.Errorf("getting events: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if events == nil {
+		events = []entry{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Events []entry `json:"events"`
+		Total  int64   `json:"total"`
+	}{events, total}); err != nil {
+		http.Error(w, fmt.Errorf("encoding response: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func scheduleCleanup() {
+	channels, err := getChannels(db)
+	if err != nil {
+		logger.WithError(err).Error("listing channels for cleanup")
+		return
+	}
+
+	for _, channel := range channels {
+		retention := getModuleConfig(moduleName, channel).MustDuration("retention", new(defaultRetention))
+		if retention <= 0 {
+			// Retention disabled, keep all events
+			continue
+		}
+
+		if err = cleanupChannel(db, channel, time.Now().Add(-retention)); err != nil {
+			logger.WithError(err).WithField("channel", channel).Error("cleaning up event log")
+		}
+	}
+}
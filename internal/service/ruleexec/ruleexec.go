@@ -0,0 +1,222 @@
+// Package ruleexec contains a service to record rule executions and
+// the outcome of their actions in a database
+package ruleexec
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+const (
+	// DefaultMaxAge is the retention applied to executions when no
+	// other retention is configured
+	DefaultMaxAge = 30 * 24 * time.Hour
+	// DefaultMaxEntries is the number of executions kept per rule when
+	// no other retention is configured
+	DefaultMaxEntries = 100
+)
+
+type (
+	// ActionExecution contains the outcome of a single action executed
+	// as part of a rule execution
+	ActionExecution struct {
+		Duration time.Duration `json:"duration"`
+		Error    string        `json:"error,omitempty"`
+		Stopped  bool          `json:"stopped,omitempty"`
+		Type     string        `json:"type"`
+	}
+
+	// Execution represents a single execution of a rule
+	Execution struct {
+		ID          uint64            `gorm:"primaryKey" json:"id"`
+		RuleUUID    string            `gorm:"index:idx_rule_executions_rule" json:"rule_uuid"`
+		ExecutedAt  time.Time         `gorm:"index" json:"executed_at"`
+		Channel     string            `json:"channel,omitempty"`
+		User        string            `json:"user,omitempty"`
+		Event       string            `json:"event,omitempty"`
+		Actions     []ActionExecution `gorm:"serializer:json" json:"actions"`
+		CooldownSet bool              `json:"cooldown_set"`
+		Error       string            `json:"error,omitempty"`
+	}
+
+	// Service implements the rule execution history
+	Service struct {
+		db database.Connector
+
+		maxAge     time.Duration
+		maxEntries int64
+		lock       sync.RWMutex
+	}
+)
+
+// TableName overrides the default table name to keep it descriptive
+func (Execution) TableName() string { return "rule_executions" }
+
+// New creates a new Service and registers the retention cleanup into
+// the given cronService if one is given
+func New(db database.Connector, cronService *cron.Cron) (*Service, error) {
+	s := &Service{
+		db:         db,
+		maxAge:     DefaultMaxAge,
+		maxEntries: DefaultMaxEntries,
+	}
+
+	if cronService != nil {
+		if _, err := cronService.AddFunc("@every 1h", s.cleanupCron); err != nil {
+			return nil, fmt.Errorf("registering execution cleanup cron: %w", err)
+		}
+	}
+
+	if err := s.db.DB().AutoMigrate(&Execution{}); err != nil {
+		return nil, fmt.Errorf("applying migrations: %w", err)
+	}
+
+	return s, nil
+}
+
+// AddAction appends the outcome of an action to the execution
+func (e *Execution) AddAction(ra *plugins.RuleAction, took time.Duration, err error) {
+	ae := ActionExecution{
+		Duration: took,
+		Type:     ra.Type,
+	}
+
+	switch {
+	case err == nil:
+		// Action executed fine
+
+	case errors.Is(err, plugins.ErrStopRuleExecution):
+		ae.Stopped = true
+
+	default:
+		ae.Error = err.Error()
+	}
+
+	e.Actions = append(e.Actions, ae)
+}
+
+// Cleanup removes all executions exceeding the configured retention
+func (s *Service) Cleanup() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.maxAge > 0 {
+		if err := helpers.RetryTransaction(s.db.DB(), func(tx *gorm.DB) error {
+			return tx.Where("executed_at < ?", time.Now().Add(-s.maxAge).UTC()).
+				Delete(&Execution{}).
+				Error
+		}); err != nil {
+			return fmt.Errorf("deleting outdated executions: %w", err)
+		}
+	}
+
+	if s.maxEntries <= 0 {
+		return nil
+	}
+
+	var ruleUUIDs []string
+	if err := helpers.Retry(func() error {
+		return s.db.DB().Model(&Execution{}).
+			Group("rule_uuid").
+			Having("COUNT(*) > ?", s.maxEntries).
+			Pluck("rule_uuid", &ruleUUIDs).
+			Error
+	}); err != nil {
+		return fmt.Errorf("getting rules exceeding retention: %w", err)
+	}
+
+	for _, ruleUUID := range ruleUUIDs {
+		if err := s.cleanupRule(ruleUUID); err != nil {
+			return fmt.Errorf("cleaning up rule %q: %w", ruleUUID, err)
+		}
+	}
+
+	return nil
+}
+
+// CopyDatabase enables the service to migrate to a new database
+func (*Service) CopyDatabase(src, target *gorm.DB) error {
+	return database.CopyObjects(src, target, &Execution{}) //nolint:wrapcheck // Helper in own package
+}
+
+// ListExecutions returns the latest executions of the given rule,
+// newest first, limited to the given number of entries
+func (s *Service) ListExecutions(ruleUUID string, limit int) (out []Execution, err error) {
+	if err = helpers.Retry(func() error {
+		return s.db.DB().
+			Where("rule_uuid = ?", ruleUUID).
+			Order("executed_at DESC").
+			Order("id DESC").
+			Limit(limit).
+			Find(&out).
+			Error
+	}); err != nil {
+		return nil, fmt.Errorf("listing executions: %w", err)
+	}
+
+	return out, nil
+}
+
+// Record stores the given execution
+func (s *Service) Record(e Execution) error {
+	e.ExecutedAt = e.ExecutedAt.UTC()
+
+	if err := helpers.RetryTransaction(s.db.DB(), func(tx *gorm.DB) error {
+		return tx.Create(&e).Error
+	}); err != nil {
+		return fmt.Errorf("storing execution: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRetention sets the maximum age and the maximum number of
+// executions kept per rule, zero values disable the respective limit
+func (s *Service) UpdateRetention(maxAge time.Duration, maxEntries int64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.maxAge = maxAge
+	s.maxEntries = maxEntries
+}
+
+func (s *Service) cleanupCron() {
+	if err := s.Cleanup(); err != nil {
+		logrus.WithError(err).Error("cleaning up rule executions")
+	}
+}
+
+func (s *Service) cleanupRule(ruleUUID string) error {
+	var keepIDs []uint64
+	if err := helpers.Retry(func() error {
+		return s.db.DB().Model(&Execution{}).
+			Where("rule_uuid = ?", ruleUUID).
+			Order("executed_at DESC").
+			Order("id DESC").
+			Limit(int(s.maxEntries)).
+			Pluck("id", &keepIDs).
+			Error
+	}); err != nil {
+		return fmt.Errorf("getting executions to keep: %w", err)
+	}
+
+	if err := helpers.RetryTransaction(s.db.DB(), func(tx *gorm.DB) error {
+		return tx.Where("rule_uuid = ? AND id NOT IN ?", ruleUUID, keepIDs).
+			Delete(&Execution{}).
+			Error
+	}); err != nil {
+		return fmt.Errorf("deleting executions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package ruleexec
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/database"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+func TestExecutionRoundtrip(t *testing.T) {
+	dbc := database.GetTestDatabase(t)
+	s, err := New(dbc, nil)
+	require.NoError(t, err, "creating service")
+
+	ruleUUID := "7c4bdfb7-7d32-4a4b-9b50-4c9bbd0fd5b2"
+
+	e := Execution{
+		RuleUUID:   ruleUUID,
+		ExecutedAt: time.Now(),
+		Channel:    "#mychannel",
+		User:       "amy",
+	}
+	e.AddAction(&plugins.RuleAction{Type: "respond"}, time.Millisecond, nil)
+	e.AddAction(&plugins.RuleAction{Type: "stopexec"}, time.Millisecond, plugins.ErrStopRuleExecution)
+	e.AddAction(&plugins.RuleAction{Type: "timeout"}, time.Millisecond, errors.New("test"))
+
+	require.NoError(t, s.Record(e), "recording execution")
+
+	execs, err := s.ListExecutions(ruleUUID, 10)
+	require.NoError(t, err, "listing executions")
+	require.Len(t, execs, 1)
+
+	assert.Equal(t, "#mychannel", execs[0].Channel)
+	assert.Equal(t, []ActionExecution{
+		{Duration: time.Millisecond, Type: "respond"},
+		{Duration: time.Millisecond, Stopped: true, Type: "stopexec"},
+		{Duration: time.Millisecond, Error: "test", Type: "timeout"},
+	}, execs[0].Actions)
+
+	execs, err = s.ListExecutions("unknown", 10)
+	require.NoError(t, err, "listing executions of unknown rule")
+	assert.Empty(t, execs)
+}
+
+func TestExecutionRetention(t *testing.T) {
+	dbc := database.GetTestDatabase(t)
+	s, err := New(dbc, nil)
+	require.NoError(t, err, "creating service")
+
+	s.UpdateRetention(time.Hour, 2)
+
+	for i, age := range []time.Duration{2 * time.Hour, 3 * time.Minute, 2 * time.Minute, time.Minute} {
+		require.NoError(t, s.Record(Execution{
+			RuleUUID:   "rule-a",
+			ExecutedAt: time.Now().Add(-age),
+			User:       string(rune('a' + i)),
+		}), "recording execution")
+	}
+
+	require.NoError(t, s.Record(Execution{
+		RuleUUID:   "rule-b",
+		ExecutedAt: time.Now().Add(-time.Minute),
+	}), "recording execution")
+
+	require.NoError(t, s.Cleanup(), "cleaning up")
+
+	execs, err := s.ListExecutions("rule-a", 10)
+	require.NoError(t, err, "listing executions")
+	require.Len(t, execs, 2)
+	assert.Equal(t, "d", execs[0].User)
+	assert.Equal(t, "c", execs[1].User)
+
+	execs, err = s.ListExecutions("rule-b", 10)
+	require.NoError(t, err, "listing executions")
+	assert.Len(t, execs, 1)
+}
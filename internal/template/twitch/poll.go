@@ -45,6 +45,6 @@ func tplTwitchLastPoll(args plugins.RegistrationArguments) {
 			Template:    `Last Poll: {{ (lastPoll .channel).Title }}`,
 			FakedOutput: "Last Poll: Und wie siehts im Template aus?",
 		},
-		Remarks: "See schema of returned object in [`pkg/twitch/polls.go#L13`](https://github.com/Luzifer/twitch-bot/blob/master/pkg/twitch/polls.go#L13)",
+		Remarks: "See schema of returned object in [`pkg/twitch/polls.go#L24`](https://github.com/Luzifer/twitch-bot/blob/master/pkg/twitch/polls.go#L24)",
 	})
 }
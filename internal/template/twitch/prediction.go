@@ -0,0 +1,50 @@
+package twitch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+func init() {
+	regFn = append(
+		regFn,
+		tplTwitchLastPrediction,
+	)
+}
+
+func tplTwitchLastPrediction(args plugins.RegistrationArguments) {
+	args.RegisterTemplateFunction("lastPrediction", plugins.GenericTemplateFunctionGetter(func(username string) (*twitch.PredictionInfo, error) {
+		hasPredictionAccess, err := args.HasAnyPermissionForChannel(username, twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions)
+		if err != nil {
+			return nil, fmt.Errorf("checking read-prediction-permission: %w", err)
+		}
+
+		if !hasPredictionAccess {
+			return nil, fmt.Errorf("not authorized to read predictions for channel %s", username)
+		}
+
+		tc, err := args.GetTwitchClientForChannel(strings.TrimLeft(username, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("getting twitch client for user: %w", err)
+		}
+
+		prediction, err := tc.GetLatestPrediction(context.Background(), strings.TrimLeft(username, "#"))
+		if err != nil {
+			return prediction, fmt.Errorf("getting last prediction: %w", err)
+		}
+
+		return prediction, nil
+	}), plugins.TemplateFuncDocumentation{
+		Description: "Gets the last (currently running or archived) prediction for the given channel (the channel must have given extended permission for prediction access!)",
+		Syntax:      "lastPrediction <channel>",
+		Example: &plugins.TemplateFuncDocumentationExample{
+			Template:    `Last Prediction: {{ (lastPrediction .channel).Title }}`,
+			FakedOutput: "Last Prediction: Schaffen wir den Boss im ersten Versuch?",
+		},
+		Remarks: "See schema of returned object in [`pkg/twitch/predictions.go#L24`](https://github.com/Luzifer/twitch-bot/blob/master/pkg/twitch/predictions.go#L24)",
+	})
+}
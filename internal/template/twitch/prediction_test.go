@@ -0,0 +1,110 @@
+package twitch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// newTestTwitchClient returns a client talking to a fake Twitch API:
+// tokens are always valid, every user has the ID 123 and all other
+// requests are answered by the given helix handler
+func newTestTwitchClient(t *testing.T, helix http.HandlerFunc) *twitch.Client {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		resp.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Host == "id.twitch.tv" && r.URL.Path == "/oauth2/validate":
+			_, _ = resp.WriteString(`{"client_id":"id","login":"bot","expires_in":3600}`)
+
+		case r.URL.Host == "id.twitch.tv" && r.URL.Path == "/oauth2/token":
+			_, _ = resp.WriteString(`{"access_token":"apptoken","expires_in":3600}`)
+
+		case r.URL.Path == "/helix/users":
+			_, _ = resp.WriteString(`{"data":[{"id":"123","login":"` + r.URL.Query().Get("login") + `"}]}`)
+
+		default:
+			helix(resp, r)
+		}
+
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	return twitch.New("id", "secret", "token", "")
+}
+
+// getTestTemplateFunction registers the template functions using the
+// given arguments and returns the one with the given name
+func getTestTemplateFunction(t *testing.T, reg func(plugins.RegistrationArguments), args plugins.RegistrationArguments, name string) any {
+	t.Helper()
+
+	var fn any
+	args.RegisterTemplateFunction = func(n string, fg plugins.TemplateFuncGetter, _ ...plugins.TemplateFuncDocumentation) {
+		if n == name {
+			fn = fg(nil, nil, nil)
+		}
+	}
+
+	reg(args)
+	require.NotNil(t, fn, "template function %s not registered", name)
+
+	return fn
+}
+
+func TestTplTwitchLastPrediction(t *testing.T) {
+	var requestedBroadcaster string
+	client := newTestTwitchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/helix/predictions", r.URL.Path)
+		requestedBroadcaster = r.URL.Query().Get("broadcaster_id")
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"id": "pred", "title": "Boss?", "status": twitch.PredictionStatusActive}},
+		}))
+	})
+
+	for name, tc := range map[string]struct {
+		hasPermission bool
+		expTitle      string
+		expErr        string
+	}{
+		"granted":     {true, "Boss?", ""},
+		"not granted": {false, "", "not authorized"},
+	} {
+		fn := getTestTemplateFunction(t, tplTwitchLastPrediction, plugins.RegistrationArguments{
+			GetTwitchClientForChannel: func(channel string) (*twitch.Client, error) {
+				assert.Equal(t, "testchannel", channel)
+				return client, nil
+			},
+			HasAnyPermissionForChannel: func(_ string, scopes ...string) (bool, error) {
+				assert.ElementsMatch(t, []string{twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions}, scopes)
+				return tc.hasPermission, nil
+			},
+		}, "lastPrediction").(func(string) (*twitch.PredictionInfo, error))
+
+		prediction, err := fn("#testchannel")
+		if tc.expErr != "" {
+			assert.ErrorContains(t, err, tc.expErr, name)
+			continue
+		}
+
+		require.NoError(t, err, name)
+		assert.Equal(t, tc.expTitle, prediction.Title, name)
+		assert.Equal(t, "123", requestedBroadcaster, name)
+	}
+}
@@ -385,9 +385,11 @@ func (i ircHandler) handleTwitchPrivmsg(m *irc.Message) {
 			eventFieldUserID:   m.Tags["user-id"],
 		})
 
-		logrus.WithFields(logrus.Fields(fields.Data())).Info("User spent bits in chat message")
+		if eventDedup.ShouldEmit(eventSourceIRC, *eventTypeBits, i.getChannel(m), m.User, strconv.FormatInt(bits, 10)) {
+			logrus.WithFields(logrus.Fields(fields.Data())).Info("User spent bits in chat message")
 
-		go handleMessage(i.c, m, eventTypeBits, fields)
+			go handleMessage(i.c, m, eventTypeBits, fields)
+		}
 	}
 
 	go handleMessage(i.c, m, nil, nil)
@@ -452,6 +454,9 @@ func (i ircHandler) handleTwitchUsernotice(m *irc.Message) {
 			"subscribed_months": i.tagToNumeric(m, "msg-param-cumulative-months", 0),
 			"plan":              m.Tags["msg-param-sub-plan"],
 		})
+		if !eventDedup.ShouldEmit(eventSourceIRC, *eventTypeResub, i.getChannel(m), m.Tags["login"]) {
+			return
+		}
 		logrus.WithFields(logrus.Fields(evtData.Data())).Info("User re-subscribed")
 
 		go handleMessage(i.c, m, eventTypeResub, evtData)
@@ -462,6 +467,9 @@ func (i ircHandler) handleTwitchUsernotice(m *irc.Message) {
 			"multi_month": i.tagToNumeric(m, "msg-param-multimonth-duration", 0),
 			"plan":        m.Tags["msg-param-sub-plan"],
 		})
+		if !eventDedup.ShouldEmit(eventSourceIRC, *eventTypeSub, i.getChannel(m), m.Tags["login"]) {
+			return
+		}
 		logrus.WithFields(logrus.Fields(evtData.Data())).Info("User subscribed")
 
 		go handleMessage(i.c, m, eventTypeSub, evtData)
@@ -470,6 +478,7 @@ func (i ircHandler) handleTwitchUsernotice(m *irc.Message) {
 		evtData.SetFromData(map[string]any{
 			"from":              m.Tags["login"],
 			"gifted_months":     i.tagToNumeric(m, "msg-param-gift-months", 1),
+			"is_anonymous":      i.isAnonymousGift(m),
 			"multi_month":       i.tagToNumeric(m, "msg-param-multimonth-duration", 0),
 			"origin_id":         m.Tags["msg-param-origin-id"],
 			"plan":              m.Tags["msg-param-sub-plan"],
@@ -484,12 +493,16 @@ func (i ircHandler) handleTwitchUsernotice(m *irc.Message) {
 	case "submysterygift":
 		evtData.SetFromData(map[string]any{
 			"from":         m.Tags["login"],
+			"is_anonymous": i.isAnonymousGift(m),
 			"multi_month":  i.tagToNumeric(m, "msg-param-multimonth-duration", 0),
 			"number":       i.tagToNumeric(m, "msg-param-mass-gift-count", 0),
 			"origin_id":    m.Tags["msg-param-origin-id"],
 			"plan":         m.Tags["msg-param-sub-plan"],
 			"total_gifted": i.tagToNumeric(m, "msg-param-sender-count", 0),
 		})
+		if !eventDedup.ShouldEmit(eventSourceIRC, *eventTypeSubmysterygift, i.getChannel(m), m.Tags["login"]) {
+			return
+		}
 		logrus.WithFields(logrus.Fields(evtData.Data())).Info("User gifted subs to the community")
 
 		go handleMessage(i.c, m, eventTypeSubmysterygift, evtData)
@@ -515,6 +528,12 @@ func (i ircHandler) handleTwitchWhisper(m *irc.Message) {
 	go handleMessage(i.c, m, eventTypeWhisper, nil)
 }
 
+// isAnonymousGift tells whether the gift was given anonymously: Twitch
+// either uses the dedicated message-id or the anonymous gifter user
+func (ircHandler) isAnonymousGift(m *irc.Message) bool {
+	return m.Tags["msg-id"] == "anonsubgift" || m.Tags["login"] == "ananonymousgifter"
+}
+
 func (ircHandler) tagToNumeric(m *irc.Message, tag string, fallback int64) int64 {
 	tv := m.Tags[tag]
 	if tv == "" {
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -26,6 +27,7 @@ import (
 	"github.com/Luzifer/twitch-bot/v3/internal/helpers"
 	"github.com/Luzifer/twitch-bot/v3/internal/service/access"
 	"github.com/Luzifer/twitch-bot/v3/internal/service/authcache"
+	"github.com/Luzifer/twitch-bot/v3/internal/service/ruleexec"
 	"github.com/Luzifer/twitch-bot/v3/internal/service/timer"
 	"github.com/Luzifer/twitch-bot/v3/pkg/database"
 	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
@@ -46,6 +48,12 @@ var (
 		BaseURL               string        `flag:"base-url" default:"" description:"External URL of the config-editor interface (used to generate auth-urls)"`
 		CommandTimeout        time.Duration `flag:"command-timeout" default:"30s" description:"Timeout for command execution"`
 		Config                string        `flag:"config,c" default:"./config.yaml" description:"Location of configuration file"`
+		EventSubConduitID     string        `flag:"eventsub-conduit-id" default:"" description:"ID of the EventSub conduit to attach to (conduit transport only, created when empty)"`
+		EventSubConduitShard  int64         `flag:"eventsub-conduit-shard" default:"0" description:"Shard ID served by this instance (conduit transport only)"`
+		EventSubConduitShards int64         `flag:"eventsub-conduit-shards" default:"1" description:"Number of shards in the EventSub conduit (conduit transport only)"`
+		EventSubTransport     string        `flag:"eventsub-transport" default:"websocket" description:"How to receive EventSub events: websocket, webhook, conduit"`
+		EventSubWebhookSecret string        `flag:"eventsub-webhook-secret" default:"" description:"Secret (10-100 chars) to sign EventSub webhook messages (webhook / conduit transport only)"`
+		EventSubWebhookURL    string        `flag:"eventsub-webhook-url" default:"" description:"HTTPS callback URL for EventSub webhooks (defaults to base-url + /eventsub/webhook)"`
 		IRCRateLimit          time.Duration `flag:"rate-limit" default:"1500ms" description:"How often to send a message (default: 20/30s=1500ms, if your bot is mod everywhere: 100/30s=300ms, different for known/verified bots)"`
 		LogLevel              string        `flag:"log-level" default:"info" description:"Log level (debug, info, warn, error, fatal)"`
 		PluginDir             string        `flag:"plugin-dir" default:"/usr/lib/twitch-bot" description:"Where to find and load plugins"`
@@ -70,12 +78,15 @@ var (
 
 	runID = uuid.Must(uuid.NewV4()).String()
 
-	db            database.Connector
-	accessService *access.Service
-	authService   *authcache.Service
-	timerService  *timer.Service
+	db              database.Connector
+	accessService   *access.Service
+	authService     *authcache.Service
+	ruleExecService *ruleexec.Service
+	timerService    *timer.Service
 
-	twitchClient *twitch.Client
+	twitchClient    *twitch.Client
+	twitchWatch     *twitchWatcher
+	eventSubWebhook *twitch.EventSubWebhookHandler
 
 	version = "dev"
 )
@@ -155,6 +166,10 @@ func main() {
 		log.WithError(err).Fatal("applying timer migration")
 	}
 
+	if ruleExecService, err = ruleexec.New(db, cronService); err != nil {
+		log.WithError(err).Fatal("applying rule execution migration")
+	}
+
 	// Allow config to subscribe to external rules
 	updCron := updateConfigCron()
 	if _, err = cronService.AddFunc(updCron, updateConfigFromRemote); err != nil {
@@ -261,7 +276,11 @@ func main() {
 		twitchClient = twitch.New(cfg.TwitchClient, cfg.TwitchClientSecret, "", "")
 	}
 
-	twitchWatch := newTwitchWatcher()
+	if eventSubWebhook, err = initEventSubWebhook(); err != nil {
+		log.WithError(err).Fatal("initializing EventSub webhook transport")
+	}
+
+	twitchWatch = newTwitchWatcher()
 
 	// Query may run that often as the twitchClient has an internal
 	// cache but shouldn't run more often as EventSub subscriptions
@@ -301,6 +320,15 @@ func main() {
 		log.WithField("address", listener.Addr().String()).Info("HTTP server started")
 	}
 
+	if eventSubWebhook != nil {
+		// Needs to run after the HTTP server is available as Twitch will
+		// verify the callback when attaching it to the conduit
+		if err = eventSubWebhook.Init(context.Background()); err != nil {
+			log.WithError(err).Fatal("attaching EventSub webhook to conduit")
+		}
+		log.WithField("conduit", eventSubWebhook.ConduitID()).Info("EventSub webhook transport initialized")
+	}
+
 	for _, c := range config.Channels {
 		if err := twitchWatch.AddChannel(c); err != nil {
 			log.WithError(err).WithField("channel", c).Error("Unable to add channel to watcher")
@@ -8,6 +8,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/Luzifer/twitch-bot/v3/internal/service/access"
+	"github.com/Luzifer/twitch-bot/v3/internal/service/ruleexec"
 	"github.com/Luzifer/twitch-bot/v3/internal/service/timer"
 	"github.com/Luzifer/twitch-bot/v3/pkg/database"
 )
@@ -42,6 +43,10 @@ func TestMain(m *testing.M) {
 		log.WithError(err).Fatal("applying timer migration")
 	}
 
+	if ruleExecService, err = ruleexec.New(db, nil); err != nil {
+		log.WithError(err).Fatal("applying rule execution migration")
+	}
+
 	if err = initCorePlugins(); err != nil {
 		log.WithError(err).Fatal("Unable to load core plugins")
 	}
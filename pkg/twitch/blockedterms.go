@@ -0,0 +1,144 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// BlockedTerm represents a term blocked in a channels chat
+	BlockedTerm struct {
+		BroadcasterID string     `json:"broadcaster_id"`
+		ModeratorID   string     `json:"moderator_id"`
+		ID            string     `json:"id"`
+		Text          string     `json:"text"`
+		CreatedAt     time.Time  `json:"created_at"`
+		UpdatedAt     time.Time  `json:"updated_at"`
+		ExpiresAt     *time.Time `json:"expires_at"`
+	}
+)
+
+// AddBlockedTerm adds a term to the list of blocked terms of the
+// channel. If the term is already blocked the existing term is
+// returned.
+func (c *Client) AddBlockedTerm(ctx context.Context, channel, text string) (*BlockedTerm, error) {
+	params, err := c.blockedTermsParams(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(map[string]string{
+		"text": text,
+	}); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var payload struct {
+		Data []BlockedTerm `json:"data"`
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodPost,
+		OKStatus: http.StatusOK,
+		Body:     body,
+		Out:      &payload,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/moderation/blocked_terms?%s", params.Encode()),
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Data) != 1 {
+		return nil, fmt.Errorf("unexpected number of terms returned: %d", len(payload.Data))
+	}
+
+	return &payload.Data[0], nil
+}
+
+// GetBlockedTerms returns all terms blocked in the channel
+func (c *Client) GetBlockedTerms(ctx context.Context, channel string) ([]BlockedTerm, error) {
+	params, err := c.blockedTermsParams(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+	params.Set("first", "100")
+
+	var (
+		out  []BlockedTerm
+		resp struct {
+			Data       []BlockedTerm `json:"data"`
+			Pagination struct {
+				Cursor string `json:"cursor"`
+			} `json:"pagination"`
+		}
+	)
+
+	for {
+		if err = c.Request(ctx, ClientRequestOpts{
+			AuthType: AuthTypeBearerToken,
+			Method:   http.MethodGet,
+			OKStatus: http.StatusOK,
+			Out:      &resp,
+			URL:      fmt.Sprintf("https://api.twitch.tv/helix/moderation/blocked_terms?%s", params.Encode()),
+		}); err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+
+		out = append(out, resp.Data...)
+
+		if resp.Pagination.Cursor == "" {
+			break
+		}
+
+		params.Set("after", resp.Pagination.Cursor)
+		resp.Pagination.Cursor = "" // Clear from struct as struct is reused
+	}
+
+	return out, nil
+}
+
+// RemoveBlockedTerm removes the term with the given ID from the list
+// of blocked terms of the channel
+func (c *Client) RemoveBlockedTerm(ctx context.Context, channel, termID string) error {
+	params, err := c.blockedTermsParams(ctx, channel)
+	if err != nil {
+		return err
+	}
+	params.Set("id", termID)
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodDelete,
+		OKStatus: http.StatusNoContent,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/moderation/blocked_terms?%s", params.Encode()),
+	}); err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) blockedTermsParams(ctx context.Context, channel string) (url.Values, error) {
+	botID, _, err := c.GetAuthorizedUser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting bot user-id: %w", err)
+	}
+
+	channelID, err := c.GetIDForUsername(ctx, strings.TrimLeft(channel, "#@"))
+	if err != nil {
+		return nil, fmt.Errorf("getting channel user-id: %w", err)
+	}
+
+	params := make(url.Values)
+	params.Set("broadcaster_id", channelID)
+	params.Set("moderator_id", botID)
+
+	return params, nil
+}
@@ -0,0 +1,209 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Collection of known redemption states
+const (
+	RedemptionStatusCanceled    = "CANCELED"
+	RedemptionStatusFulfilled   = "FULFILLED"
+	RedemptionStatusUnfulfilled = "UNFULFILLED"
+)
+
+type (
+	// CustomReward contains information about a Channel Points custom
+	// reward
+	CustomReward struct {
+		ID                  string `json:"id"`
+		BroadcasterID       string `json:"broadcaster_id"`
+		BroadcasterLogin    string `json:"broadcaster_login"`
+		BroadcasterName     string `json:"broadcaster_name"`
+		Title               string `json:"title"`
+		Prompt              string `json:"prompt"`
+		Cost                int64  `json:"cost"`
+		BackgroundColor     string `json:"background_color"`
+		IsEnabled           bool   `json:"is_enabled"`
+		IsUserInputRequired bool   `json:"is_user_input_required"`
+		IsPaused            bool   `json:"is_paused"`
+		IsInStock           bool   `json:"is_in_stock"`
+
+		MaxPerStreamSetting struct {
+			IsEnabled    bool  `json:"is_enabled"`
+			MaxPerStream int64 `json:"max_per_stream"`
+		} `json:"max_per_stream_setting"`
+		MaxPerUserPerStreamSetting struct {
+			IsEnabled           bool  `json:"is_enabled"`
+			MaxPerUserPerStream int64 `json:"max_per_user_per_stream"`
+		} `json:"max_per_user_per_stream_setting"`
+		GlobalCooldownSetting struct {
+			IsEnabled             bool  `json:"is_enabled"`
+			GlobalCooldownSeconds int64 `json:"global_cooldown_seconds"`
+		} `json:"global_cooldown_setting"`
+
+		ShouldRedemptionsSkipRequestQueue bool `json:"should_redemptions_skip_request_queue"`
+	}
+
+	// CustomRewardUpdate contains the fields to set when creating or
+	// updating a custom reward. Fields being nil are not sent and
+	// therefore not changed by an update.
+	CustomRewardUpdate struct {
+		Title                             *string `json:"title,omitempty"`
+		Prompt                            *string `json:"prompt,omitempty"`
+		Cost                              *int64  `json:"cost,omitempty"`
+		BackgroundColor                   *string `json:"background_color,omitempty"`
+		IsEnabled                         *bool   `json:"is_enabled,omitempty"`
+		IsPaused                          *bool   `json:"is_paused,omitempty"`
+		IsUserInputRequired               *bool   `json:"is_user_input_required,omitempty"`
+		IsMaxPerStreamEnabled             *bool   `json:"is_max_per_stream_enabled,omitempty"`
+		MaxPerStream                      *int64  `json:"max_per_stream,omitempty"`
+		IsMaxPerUserPerStreamEnabled      *bool   `json:"is_max_per_user_per_stream_enabled,omitempty"`
+		MaxPerUserPerStream               *int64  `json:"max_per_user_per_stream,omitempty"`
+		IsGlobalCooldownEnabled           *bool   `json:"is_global_cooldown_enabled,omitempty"`
+		GlobalCooldownSeconds             *int64  `json:"global_cooldown_seconds,omitempty"`
+		ShouldRedemptionsSkipRequestQueue *bool   `json:"should_redemptions_skip_request_queue,omitempty"`
+	}
+)
+
+// CreateCustomReward creates a new custom reward in the given channel
+// (the title and cost are required by Twitch)
+func (c *Client) CreateCustomReward(ctx context.Context, channel string, reward CustomRewardUpdate) (*CustomReward, error) {
+	channelID, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for channel name: %w", err)
+	}
+
+	return c.executeCustomRewardRequest(ctx, http.MethodPost, url.Values{"broadcaster_id": []string{channelID}}, reward)
+}
+
+// GetCustomRewardByIDOrTitle looks up a manageable custom reward in
+// the given channel by its ID or its title (case-insensitive)
+func (c *Client) GetCustomRewardByIDOrTitle(ctx context.Context, channel, idOrTitle string) (*CustomReward, error) {
+	rewards, err := c.GetCustomRewards(ctx, channel, true)
+	if err != nil {
+		return nil, fmt.Errorf("getting rewards: %w", err)
+	}
+
+	for i := range rewards {
+		if rewards[i].ID == idOrTitle || strings.EqualFold(rewards[i].Title, idOrTitle) {
+			return &rewards[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no manageable reward %q found", idOrTitle)
+}
+
+// GetCustomRewards returns the custom rewards of the given channel.
+// If onlyManageable is set, only rewards created by the client-id of
+// this client are returned (only those can be updated through the API).
+func (c *Client) GetCustomRewards(ctx context.Context, channel string, onlyManageable bool) ([]CustomReward, error) {
+	channelID, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for channel name: %w", err)
+	}
+
+	params := make(url.Values)
+	params.Set("broadcaster_id", channelID)
+	if onlyManageable {
+		params.Set("only_manageable_rewards", "true")
+	}
+
+	var payload struct {
+		Data []CustomReward `json:"data"`
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodGet,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/channel_points/custom_rewards?%s", params.Encode()),
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// UpdateCustomReward updates the custom reward with the given ID
+// (the reward must have been created by the client-id of this client)
+func (c *Client) UpdateCustomReward(ctx context.Context, channel, rewardID string, update CustomRewardUpdate) (*CustomReward, error) {
+	channelID, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for channel name: %w", err)
+	}
+
+	return c.executeCustomRewardRequest(ctx, http.MethodPatch, url.Values{
+		"broadcaster_id": []string{channelID},
+		"id":             []string{rewardID},
+	}, update)
+}
+
+// UpdateRedemptionStatus marks the given redemption as fulfilled or
+// canceled (refunding the spent channel points). The reward must have
+// been created by the client-id of this client.
+func (c *Client) UpdateRedemptionStatus(ctx context.Context, channel, rewardID, redemptionID, status string) error {
+	channelID, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return fmt.Errorf("getting ID for channel name: %w", err)
+	}
+
+	params := make(url.Values)
+	params.Set("broadcaster_id", channelID)
+	params.Set("id", redemptionID)
+	params.Set("reward_id", rewardID)
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(struct {
+		Status string `json:"status"`
+	}{Status: status}); err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Body:     body,
+		Method:   http.MethodPatch,
+		OKStatus: http.StatusOK,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/channel_points/custom_rewards/redemptions?%s", params.Encode()),
+	}); err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) executeCustomRewardRequest(ctx context.Context, method string, params url.Values, reward CustomRewardUpdate) (*CustomReward, error) {
+	body := new(bytes.Buffer)
+	if err := json.NewEncoder(body).Encode(reward); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var payload struct {
+		Data []*CustomReward `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Body:     body,
+		Method:   method,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/channel_points/custom_rewards?%s", params.Encode()),
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Data) < 1 {
+		return nil, errors.New("no reward returned")
+	}
+
+	return payload.Data[0], nil
+}
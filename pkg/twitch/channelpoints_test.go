@@ -0,0 +1,84 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomRewardRequests(t *testing.T) {
+	var (
+		reqMethod string
+		reqQuery  url.Values
+		reqBody   string
+	)
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		reqMethod, reqQuery, reqBody = r.Method, r.URL.Query(), ""
+
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			reqBody = string(body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/helix/channel_points/custom_rewards":
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"id": "r1", "title": "Hydrate"}, {"id": "r2", "title": "Stretch"}},
+			}))
+
+		case "/helix/channel_points/custom_rewards/redemptions":
+			_, err := w.Write([]byte(`{"data":[]}`))
+			assert.NoError(t, err)
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	for ref, expID := range map[string]string{
+		"r2":      "r2",
+		"hydrate": "r1",
+		"STRETCH": "r2",
+	} {
+		reward, err := c.GetCustomRewardByIDOrTitle(context.Background(), "testchannel", ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, expID, reward.ID, ref)
+		assert.Equal(t, url.Values{"broadcaster_id": {"123"}, "only_manageable_rewards": {"true"}}, reqQuery, ref)
+	}
+
+	_, err := c.GetCustomRewardByIDOrTitle(context.Background(), "testchannel", "r3")
+	assert.Error(t, err)
+
+	// Update only sends the fields being set
+	_, err = c.UpdateCustomReward(context.Background(), "testchannel", "r1", CustomRewardUpdate{
+		Cost:     new(int64(500)),
+		IsPaused: new(false),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPatch, reqMethod)
+	assert.Equal(t, url.Values{"broadcaster_id": {"123"}, "id": {"r1"}}, reqQuery)
+	assert.JSONEq(t, `{"cost":500,"is_paused":false}`, reqBody)
+
+	_, err = c.CreateCustomReward(context.Background(), "testchannel", CustomRewardUpdate{
+		Title: new("Hydrate"),
+		Cost:  new(int64(100)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodPost, reqMethod)
+	assert.Equal(t, url.Values{"broadcaster_id": {"123"}}, reqQuery)
+	assert.JSONEq(t, `{"title":"Hydrate","cost":100}`, reqBody)
+
+	require.NoError(t, c.UpdateRedemptionStatus(context.Background(), "testchannel", "r1", "red1", RedemptionStatusCanceled))
+	assert.Equal(t, http.MethodPatch, reqMethod)
+	assert.Equal(t, url.Values{"broadcaster_id": {"123"}, "id": {"red1"}, "reward_id": {"r1"}}, reqQuery)
+	assert.JSONEq(t, `{"status":"CANCELED"}`, reqBody)
+}
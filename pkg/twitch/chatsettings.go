@@ -0,0 +1,97 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// ChatSettings represents the (moderator-manageable) settings of a
+	// chat. When updating settings only non-nil fields are sent to
+	// Twitch and therefore only those are changed.
+	ChatSettings struct {
+		EmoteMode            *bool  `json:"emote_mode,omitempty"`
+		FollowerMode         *bool  `json:"follower_mode,omitempty"`
+		FollowerModeDuration *int64 `json:"follower_mode_duration,omitempty"` // Minutes
+		SlowMode             *bool  `json:"slow_mode,omitempty"`
+		SlowModeWaitTime     *int64 `json:"slow_mode_wait_time,omitempty"` // Seconds
+		SubscriberMode       *bool  `json:"subscriber_mode,omitempty"`
+		UniqueChatMode       *bool  `json:"unique_chat_mode,omitempty"`
+	}
+)
+
+// GetChatSettings retrieves the current settings of the channels chat
+func (c *Client) GetChatSettings(ctx context.Context, channel string) (settings ChatSettings, err error) {
+	botID, channelID, err := c.chatSettingsIDs(ctx, channel)
+	if err != nil {
+		return settings, err
+	}
+
+	var payload struct {
+		Data []ChatSettings `json:"data"`
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodGet,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL: fmt.Sprintf(
+			"https://api.twitch.tv/helix/chat/settings?broadcaster_id=%s&moderator_id=%s",
+			channelID, botID,
+		),
+	}); err != nil {
+		return settings, fmt.Errorf("executing request: %w", err)
+	}
+
+	if l := len(payload.Data); l != 1 {
+		return settings, fmt.Errorf("unexpected number of settings returned: %d", l)
+	}
+
+	return payload.Data[0], nil
+}
+
+// UpdateChatSettings changes the settings of the channels chat. Only
+// the non-nil fields of the settings are updated.
+func (c *Client) UpdateChatSettings(ctx context.Context, channel string, settings ChatSettings) error {
+	botID, channelID, err := c.chatSettingsIDs(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(settings); err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodPatch,
+		OKStatus: http.StatusOK,
+		Body:     body,
+		URL: fmt.Sprintf(
+			"https://api.twitch.tv/helix/chat/settings?broadcaster_id=%s&moderator_id=%s",
+			channelID, botID,
+		),
+	}); err != nil {
+		return fmt.Errorf("executing update request: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) chatSettingsIDs(ctx context.Context, channel string) (botID, channelID string, err error) {
+	if botID, _, err = c.GetAuthorizedUser(ctx); err != nil {
+		return "", "", fmt.Errorf("getting bot user-id: %w", err)
+	}
+
+	if channelID, err = c.GetIDForUsername(ctx, strings.TrimLeft(channel, "#@")); err != nil {
+		return "", "", fmt.Errorf("getting channel user-id: %w", err)
+	}
+
+	return botID, channelID, nil
+}
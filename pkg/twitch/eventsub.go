@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"reflect"
 	"time"
 
 	"github.com/mitchellh/hashstructure/v2"
@@ -15,21 +16,36 @@ import (
 
 // Collection of known EventSub event-types
 const (
+	EventSubEventTypeAutomodMessageHold                    = "automod.message.hold"
+	EventSubEventTypeAutomodMessageUpdate                  = "automod.message.update"
 	EventSubEventTypeChannelAdBreakBegin                   = "channel.ad_break.begin"
+	EventSubEventTypeChannelCheer                          = "channel.cheer"
 	EventSubEventTypeChannelFollow                         = "channel.follow"
 	EventSubEventTypeChannelPointCustomRewardRedemptionAdd = "channel.channel_points_custom_reward_redemption.add"
 	EventSubEventTypeChannelHypetrainBegin                 = "channel.hype_train.begin"
 	EventSubEventTypeChannelHypetrainProgress              = "channel.hype_train.progress"
 	EventSubEventTypeChannelHypetrainEnd                   = "channel.hype_train.end"
+	EventSubEventTypeChannelModerate                       = "channel.moderate"
 	EventSubEventTypeChannelRaid                           = "channel.raid"
 	EventSubEventTypeChannelShoutoutCreate                 = "channel.shoutout.create"
 	EventSubEventTypeChannelShoutoutReceive                = "channel.shoutout.receive"
+	EventSubEventTypeChannelSubscribe                      = "channel.subscribe"
+	EventSubEventTypeChannelSubscriptionGift               = "channel.subscription.gift"
+	EventSubEventTypeChannelSubscriptionMessage            = "channel.subscription.message"
+	EventSubEventTypeChannelUnbanRequestCreate             = "channel.unban_request.create"
+	EventSubEventTypeChannelUnbanRequestResolve            = "channel.unban_request.resolve"
 	EventSubEventTypeChannelUpdate                         = "channel.update"
 	EventSubEventTypeChannelPollBegin                      = "channel.poll.begin"
 	EventSubEventTypeChannelPollEnd                        = "channel.poll.end"
 	EventSubEventTypeChannelPollProgress                   = "channel.poll.progress"
+	EventSubEventTypeChannelPredictionBegin                = "channel.prediction.begin"
+	EventSubEventTypeChannelPredictionEnd                  = "channel.prediction.end"
+	EventSubEventTypeChannelPredictionLock                 = "channel.prediction.lock"
+	EventSubEventTypeChannelPredictionProgress             = "channel.prediction.progress"
 	EventSubEventTypeChannelSuspiciousUserMessage          = "channel.suspicious_user.message"
 	EventSubEventTypeChannelSuspiciousUserUpdate           = "channel.suspicious_user.update"
+	EventSubEventTypeChannelWarningAcknowledge             = "channel.warning.acknowledge"
+	EventSubEventTypeChannelWarningSend                    = "channel.warning.send"
 	EventSubEventTypeStreamOffline                         = "stream.offline"
 	EventSubEventTypeStreamOnline                          = "stream.online"
 	EventSubEventTypeUserAuthorizationRevoke               = "user.authorization.revoke"
@@ -73,6 +89,64 @@ type (
 		RequesterUserName    string    `json:"requester_user_name"`
 	}
 
+	// EventSubEventAutomodMessageHold contains the payload for a
+	// message held by AutoMod (v2) event (either the automod or the
+	// blocked_term object is present depending on the reason)
+	EventSubEventAutomodMessageHold struct {
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+		MessageID            string `json:"message_id"`
+		Message              struct {
+			Text string `json:"text"`
+		} `json:"message"`
+		Reason  string `json:"reason"` // Can be "automod" or "blocked_term"
+		Automod *struct {
+			Category string `json:"category"`
+			Level    int64  `json:"level"`
+		} `json:"automod"`
+		BlockedTerm *struct {
+			TermsFound []struct {
+				TermID                    string `json:"term_id"`
+				OwnerBroadcasterUserID    string `json:"owner_broadcaster_user_id"`
+				OwnerBroadcasterUserLogin string `json:"owner_broadcaster_user_login"`
+				OwnerBroadcasterUserName  string `json:"owner_broadcaster_user_name"`
+				Boundary                  struct {
+					StartPos int `json:"start_pos"`
+					EndPos   int `json:"end_pos"`
+				} `json:"boundary"`
+			} `json:"terms_found"`
+		} `json:"blocked_term"`
+		HeldAt time.Time `json:"held_at"`
+	}
+
+	// EventSubEventAutomodMessageUpdate contains the payload for a
+	// held message being approved / denied or expiring (v2)
+	EventSubEventAutomodMessageUpdate struct {
+		EventSubEventAutomodMessageHold
+		ModeratorUserID    string `json:"moderator_user_id"`
+		ModeratorUserLogin string `json:"moderator_user_login"`
+		ModeratorUserName  string `json:"moderator_user_name"`
+		Status             string `json:"status"` // Can be "Approved", "Denied" or "Expired"
+	}
+
+	// EventSubEventChannelCheer contains the payload for a cheer event
+	// (user fields are empty for anonymous cheers)
+	EventSubEventChannelCheer struct {
+		IsAnonymous          bool   `json:"is_anonymous"`
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		Message              string `json:"message"`
+		Bits                 int64  `json:"bits"`
+	}
+
 	// EventSubEventChannelPointCustomRewardRedemptionAdd contains the
 	// payload for an channel-point redeem event
 	EventSubEventChannelPointCustomRewardRedemptionAdd struct {
@@ -94,6 +168,157 @@ type (
 		RedeemedAt time.Time `json:"redeemed_at"`
 	}
 
+	// EventSubEventChannelModerate contains the payload for a
+	// moderator action (v2) event (only the object matching the
+	// action is present, see docs!)
+	EventSubEventChannelModerate struct {
+		BroadcasterUserID          string  `json:"broadcaster_user_id"`
+		BroadcasterUserLogin       string  `json:"broadcaster_user_login"`
+		BroadcasterUserName        string  `json:"broadcaster_user_name"`
+		SourceBroadcasterUserID    *string `json:"source_broadcaster_user_id"`
+		SourceBroadcasterUserLogin *string `json:"source_broadcaster_user_login"`
+		SourceBroadcasterUserName  *string `json:"source_broadcaster_user_name"`
+		ModeratorUserID            string  `json:"moderator_user_id"`
+		ModeratorUserLogin         string  `json:"moderator_user_login"`
+		ModeratorUserName          string  `json:"moderator_user_name"`
+		Action                     string  `json:"action"`
+
+		Followers *struct {
+			FollowDurationMinutes int64 `json:"follow_duration_minutes"`
+		} `json:"followers"`
+		Slow *struct {
+			WaitTimeSeconds int64 `json:"wait_time_seconds"`
+		} `json:"slow"`
+		VIP       *EventSubEventChannelModerateUser `json:"vip"`
+		Unvip     *EventSubEventChannelModerateUser `json:"unvip"`
+		Mod       *EventSubEventChannelModerateUser `json:"mod"`
+		Unmod     *EventSubEventChannelModerateUser `json:"unmod"`
+		Ban       *EventSubEventChannelModerateUser `json:"ban"`
+		Unban     *EventSubEventChannelModerateUser `json:"unban"`
+		Timeout   *EventSubEventChannelModerateUser `json:"timeout"`
+		Untimeout *EventSubEventChannelModerateUser `json:"untimeout"`
+		Raid      *EventSubEventChannelModerateUser `json:"raid"`
+		Unraid    *EventSubEventChannelModerateUser `json:"unraid"`
+		Delete    *EventSubEventChannelModerateUser `json:"delete"`
+		Warn      *EventSubEventChannelModerateUser `json:"warn"`
+
+		AutomodTerms *struct {
+			Action      string   `json:"action"`
+			List        string   `json:"list"`
+			Terms       []string `json:"terms"`
+			FromAutomod bool     `json:"from_automod"`
+		} `json:"automod_terms"`
+		UnbanRequest *struct {
+			EventSubEventChannelModerateUser
+			IsApproved       bool   `json:"is_approved"`
+			ModeratorMessage string `json:"moderator_message"`
+		} `json:"unban_request"`
+
+		SharedChatBan       *EventSubEventChannelModerateUser `json:"shared_chat_ban"`
+		SharedChatUnban     *EventSubEventChannelModerateUser `json:"shared_chat_unban"`
+		SharedChatTimeout   *EventSubEventChannelModerateUser `json:"shared_chat_timeout"`
+		SharedChatUntimeout *EventSubEventChannelModerateUser `json:"shared_chat_untimeout"`
+		SharedChatDelete    *EventSubEventChannelModerateUser `json:"shared_chat_delete"`
+	}
+
+	// EventSubEventChannelModerateUser contains the target user of a
+	// moderator action together with the action specific fields (not
+	// all fields are present for all actions)
+	EventSubEventChannelModerateUser struct {
+		UserID         string     `json:"user_id"`
+		UserLogin      string     `json:"user_login"`
+		UserName       string     `json:"user_name"`
+		Reason         string     `json:"reason"`
+		ExpiresAt      *time.Time `json:"expires_at"`
+		ViewerCount    int64      `json:"viewer_count"`
+		MessageID      string     `json:"message_id"`
+		MessageBody    string     `json:"message_body"`
+		ChatRulesCited []string   `json:"chat_rules_cited"`
+	}
+
+	// EventSubEventChannelSubscribe contains the payload for a new
+	// subscription (also sent for each recipient of gifted subs)
+	EventSubEventChannelSubscribe struct {
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		Tier                 string `json:"tier"`
+		IsGift               bool   `json:"is_gift"`
+	}
+
+	// EventSubEventChannelSubscriptionGift contains the payload for
+	// one or more gifted subscriptions (user fields are empty and
+	// CumulativeTotal is nil for anonymous gifts)
+	EventSubEventChannelSubscriptionGift struct {
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		Total                int64  `json:"total"`
+		Tier                 string `json:"tier"`
+		CumulativeTotal      *int64 `json:"cumulative_total"`
+		IsAnonymous          bool   `json:"is_anonymous"`
+	}
+
+	// EventSubEventChannelSubscriptionMessage contains the payload for
+	// a shared resubscription message
+	EventSubEventChannelSubscriptionMessage struct {
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		Tier                 string `json:"tier"`
+		Message              struct {
+			Text   string `json:"text"`
+			Emotes []struct {
+				Begin int64  `json:"begin"`
+				End   int64  `json:"end"`
+				ID    string `json:"id"`
+			} `json:"emotes"`
+		} `json:"message"`
+		CumulativeMonths int64  `json:"cumulative_months"`
+		StreakMonths     *int64 `json:"streak_months"`
+		DurationMonths   int64  `json:"duration_months"`
+	}
+
+	// EventSubEventChannelUnbanRequestCreate contains the payload for
+	// a new unban request
+	EventSubEventChannelUnbanRequestCreate struct {
+		ID                   string    `json:"id"`
+		BroadcasterUserID    string    `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string    `json:"broadcaster_user_login"`
+		BroadcasterUserName  string    `json:"broadcaster_user_name"`
+		UserID               string    `json:"user_id"`
+		UserLogin            string    `json:"user_login"`
+		UserName             string    `json:"user_name"`
+		Text                 string    `json:"text"`
+		CreatedAt            time.Time `json:"created_at"`
+	}
+
+	// EventSubEventChannelUnbanRequestResolve contains the payload for
+	// an unban request being approved, denied or canceled
+	EventSubEventChannelUnbanRequestResolve struct {
+		ID                   string  `json:"id"`
+		BroadcasterUserID    string  `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string  `json:"broadcaster_user_login"`
+		BroadcasterUserName  string  `json:"broadcaster_user_name"`
+		ModeratorUserID      *string `json:"moderator_id"`
+		ModeratorUserLogin   *string `json:"moderator_login"`
+		ModeratorUserName    *string `json:"moderator_name"`
+		UserID               string  `json:"user_id"`
+		UserLogin            string  `json:"user_login"`
+		UserName             string  `json:"user_name"`
+		ResolutionText       *string `json:"resolution_text"`
+		Status               string  `json:"status"` // Can be "approved", "canceled" or "denied"
+	}
+
 	// EventSubEventChannelUpdate contains the payload for a channel
 	// update event
 	EventSubEventChannelUpdate struct {
@@ -107,6 +332,33 @@ type (
 		ContentClassificationLabels []string `json:"content_classification_labels"`
 	}
 
+	// EventSubEventChannelWarningAcknowledge contains the payload for
+	// a user acknowledging a warning
+	EventSubEventChannelWarningAcknowledge struct {
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		UserID               string `json:"user_id"`
+		UserLogin            string `json:"user_login"`
+		UserName             string `json:"user_name"`
+	}
+
+	// EventSubEventChannelWarningSend contains the payload for a
+	// warning sent to a user by a moderator
+	EventSubEventChannelWarningSend struct {
+		BroadcasterUserID    string   `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string   `json:"broadcaster_user_login"`
+		BroadcasterUserName  string   `json:"broadcaster_user_name"`
+		ModeratorUserID      string   `json:"moderator_user_id"`
+		ModeratorUserLogin   string   `json:"moderator_user_login"`
+		ModeratorUserName    string   `json:"moderator_user_name"`
+		UserID               string   `json:"user_id"`
+		UserLogin            string   `json:"user_login"`
+		UserName             string   `json:"user_name"`
+		Reason               *string  `json:"reason"`
+		ChatRulesCited       []string `json:"chat_rules_cited"`
+	}
+
 	// EventSubEventFollow contains the payload for a follow event
 	EventSubEventFollow struct {
 		UserID               string    `json:"user_id"`
@@ -179,6 +431,38 @@ type (
 		EndedAt   time.Time `json:"ended_at,omitzero"` // end
 	}
 
+	// EventSubEventPrediction contains the payload for a prediction
+	// change event (not all fields are present in all prediction
+	// events, see docs!)
+	EventSubEventPrediction struct {
+		ID                   string `json:"id"`
+		BroadcasterUserID    string `json:"broadcaster_user_id"`
+		BroadcasterUserLogin string `json:"broadcaster_user_login"`
+		BroadcasterUserName  string `json:"broadcaster_user_name"`
+		Title                string `json:"title"`
+		WinningOutcomeID     string `json:"winning_outcome_id,omitempty"` // end
+		Outcomes             []struct {
+			ID            string `json:"id"`
+			Title         string `json:"title"`
+			Color         string `json:"color"`
+			Users         int    `json:"users"`
+			ChannelPoints int    `json:"channel_points"`
+			TopPredictors []struct {
+				UserID            string `json:"user_id"`
+				UserLogin         string `json:"user_login"`
+				UserName          string `json:"user_name"`
+				ChannelPointsWon  int    `json:"channel_points_won"`
+				ChannelPointsUsed int    `json:"channel_points_used"`
+			} `json:"top_predictors"`
+		} `json:"outcomes"`
+
+		StartedAt time.Time `json:"started_at"`         // begin, progress, lock, end
+		LocksAt   time.Time `json:"locks_at,omitzero"`  // begin, progress
+		LockedAt  time.Time `json:"locked_at,omitzero"` // lock
+		Status    string    `json:"status,omitempty"`   // end -- enum(resolved, canceled)
+		EndedAt   time.Time `json:"ended_at,omitzero"`  // end
+	}
+
 	// EventSubEventRaid contains the payload for a raid event
 	EventSubEventRaid struct {
 		FromBroadcasterUserID    string `json:"from_broadcaster_user_id"`
@@ -309,12 +593,33 @@ type (
 
 	eventSubTransport struct {
 		Method    string `json:"method"`
-		Callback  string `json:"callback"`
-		Secret    string `json:"secret"` //#nosec:G117 // Intended to handle secrets
-		SessionID string `json:"session_id"`
+		Callback  string `json:"callback,omitempty"`
+		Secret    string `json:"secret,omitempty"` //#nosec:G117 // Intended to handle secrets
+		SessionID string `json:"session_id,omitempty"`
+		ConduitID string `json:"conduit_id,omitempty"`
 	}
 )
 
+// FoundTerms returns the blocked terms which caused the message to be
+// held as they were written in the message
+func (e EventSubEventAutomodMessageHold) FoundTerms() (terms []string) {
+	if e.BlockedTerm == nil {
+		return nil
+	}
+
+	text := []rune(e.Message.Text)
+	for _, t := range e.BlockedTerm.TermsFound {
+		// Boundary positions are inclusive
+		if t.Boundary.StartPos < 0 || t.Boundary.EndPos < t.Boundary.StartPos || t.Boundary.EndPos >= len(text) {
+			continue
+		}
+
+		terms = append(terms, string(text[t.Boundary.StartPos:t.Boundary.EndPos+1]))
+	}
+
+	return terms
+}
+
 // Hash generates a hashstructure hash for the condition for comparison
 func (e EventSubCondition) Hash() (string, error) {
 	h, err := hashstructure.Hash(e, hashstructure.FormatV2, &hashstructure.HashOptions{TagName: "json"})
@@ -325,6 +630,38 @@ func (e EventSubCondition) Hash() (string, error) {
 	return fmt.Sprintf("%x", h), nil
 }
 
+// Target returns the user targeted by the moderator action or nil in
+// case the action has no target user (i.e. chat settings)
+func (e EventSubEventChannelModerate) Target() *EventSubEventChannelModerateUser {
+	if e.UnbanRequest != nil {
+		return &e.UnbanRequest.EventSubEventChannelModerateUser
+	}
+
+	for _, u := range []*EventSubEventChannelModerateUser{
+		e.Ban, e.Delete, e.Mod, e.Raid, e.Timeout, e.Unban, e.Unmod, e.Unraid, e.Untimeout, e.Unvip, e.VIP, e.Warn,
+		e.SharedChatBan, e.SharedChatDelete, e.SharedChatTimeout, e.SharedChatUnban, e.SharedChatUntimeout,
+	} {
+		if u != nil {
+			return u
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) deleteEventSubSubscription(ctx context.Context, auth AuthType, id string) error {
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: auth,
+		Method:   http.MethodDelete,
+		OKStatus: http.StatusNoContent,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/eventsub/subscriptions?id=%s", url.QueryEscape(id)),
+	}); err != nil {
+		return fmt.Errorf("deleting subscription: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Client) createEventSubSubscriptionWebsocket(ctx context.Context, sub eventSubSubscription) (*eventSubSubscription, error) {
 	return c.createEventSubSubscription(ctx, AuthTypeBearerToken, sub)
 }
@@ -374,16 +711,7 @@ func (c *Client) createEventSubSubscription(ctx context.Context, auth AuthType,
 	}
 
 	if mustFetchSubsctiption {
-		params := make(url.Values)
-		params.Set("status", "enabled")
-
-		if err = c.Request(ctx, ClientRequestOpts{
-			AuthType: auth,
-			Method:   http.MethodGet,
-			OKStatus: http.StatusOK,
-			Out:      &resp,
-			URL:      fmt.Sprintf("https://api.twitch.tv/helix/eventsub/subscriptions?%s", params.Encode()),
-		}); err != nil {
+		if resp.Data, err = c.getEventSubSubscriptionsByType(ctx, auth, sub.Type); err != nil {
 			return nil, fmt.Errorf("fetching subscription: %w", err)
 		}
 	}
@@ -391,7 +719,7 @@ func (c *Client) createEventSubSubscription(ctx context.Context, auth AuthType,
 	for i := range resp.Data {
 		s := resp.Data[i]
 
-		if s.Type != sub.Type || s.Version != sub.Version {
+		if s.Type != sub.Type || s.Version != sub.Version || (s.Status != "" && s.Status != "enabled") {
 			// Not the subscription we're searching for
 			continue
 		}
@@ -412,3 +740,64 @@ func (c *Client) createEventSubSubscription(ctx context.Context, auth AuthType,
 
 	return nil, fmt.Errorf("no subscription matching input found")
 }
+
+// getEventSubSubscriptionsByType fetches all pages of subscriptions of
+// the given type. Helix only allows one filter per request and the
+// type is the only one every topic can be filtered by, so status and
+// condition need to be checked by the caller.
+func (c *Client) getEventSubSubscriptionsByType(ctx context.Context, auth AuthType, subType string) ([]eventSubSubscription, error) {
+	var (
+		out    []eventSubSubscription
+		params = make(url.Values)
+		resp   struct {
+			Data       []eventSubSubscription `json:"data"`
+			Pagination struct {
+				Cursor string `json:"cursor"`
+			} `json:"pagination"`
+		}
+	)
+
+	params.Set("type", subType)
+
+	for {
+		if err := c.Request(ctx, ClientRequestOpts{
+			AuthType: auth,
+			Method:   http.MethodGet,
+			OKStatus: http.StatusOK,
+			Out:      &resp,
+			URL:      fmt.Sprintf("https://api.twitch.tv/helix/eventsub/subscriptions?%s", params.Encode()),
+		}); err != nil {
+			return nil, fmt.Errorf("executing request: %w", err)
+		}
+
+		out = append(out, resp.Data...)
+
+		if resp.Pagination.Cursor == "" {
+			break
+		}
+
+		params.Set("after", resp.Pagination.Cursor)
+		resp.Data = nil
+		resp.Pagination.Cursor = "" // Clear from struct as struct is reused
+	}
+
+	return out, nil
+}
+
+// handleEventSubNotification passes the event of the notification to
+// all callbacks subscribed to the topic
+func handleEventSubNotification(logger *logrus.Entry, subscriptionTypes []eventSubSocketSubscriptionType, payload eventSubSocketPayloadNotification) {
+	for _, st := range subscriptionTypes {
+		if st.Event != payload.Subscription.Type || st.Version != payload.Subscription.Version || !reflect.DeepEqual(st.Condition, payload.Subscription.Condition) {
+			continue
+		}
+
+		if err := st.Callback(payload.Event); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"condition": st.Condition,
+				"event":     st.Event,
+				"version":   st.Version,
+			}).Error("callback caused error")
+		}
+	}
+}
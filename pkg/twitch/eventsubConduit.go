@@ -0,0 +1,114 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	eventSubConduit struct {
+		ID         string `json:"id"`
+		ShardCount int64  `json:"shard_count"`
+	}
+)
+
+func (c *Client) createEventSubConduit(ctx context.Context, shardCount int64) (*eventSubConduit, error) {
+	body := new(bytes.Buffer)
+	if err := json.NewEncoder(body).Encode(struct {
+		ShardCount int64 `json:"shard_count"`
+	}{ShardCount: shardCount}); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var payload struct {
+		Data []eventSubConduit `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeAppAccessToken,
+		Body:     body,
+		Method:   http.MethodPost,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      "https://api.twitch.tv/helix/eventsub/conduits",
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Data) < 1 {
+		return nil, errors.New("no conduit returned")
+	}
+
+	return &payload.Data[0], nil
+}
+
+func (c *Client) getEventSubConduits(ctx context.Context) ([]eventSubConduit, error) {
+	var payload struct {
+		Data []eventSubConduit `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeAppAccessToken,
+		Method:   http.MethodGet,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      "https://api.twitch.tv/helix/eventsub/conduits",
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+func (c *Client) updateEventSubConduitShard(ctx context.Context, conduitID string, shardID int64, transport eventSubTransport) error {
+	type shard struct {
+		ID        string            `json:"id"`
+		Transport eventSubTransport `json:"transport"`
+	}
+
+	body := new(bytes.Buffer)
+	if err := json.NewEncoder(body).Encode(struct {
+		ConduitID string  `json:"conduit_id"`
+		Shards    []shard `json:"shards"`
+	}{
+		ConduitID: conduitID,
+		Shards:    []shard{{ID: strconv.FormatInt(shardID, 10), Transport: transport}},
+	}); err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	var payload struct {
+		Errors []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			Code    string `json:"code"`
+		} `json:"errors"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeAppAccessToken,
+		Body:     body,
+		Method:   http.MethodPatch,
+		OKStatus: http.StatusAccepted,
+		Out:      &payload,
+		URL:      "https://api.twitch.tv/helix/eventsub/conduits/shards",
+	}); err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Errors) > 0 {
+		var msgs []string
+		for _, e := range payload.Errors {
+			msgs = append(msgs, fmt.Sprintf("shard %s: %s (%s)", e.ID, e.Message, e.Code))
+		}
+		return fmt.Errorf("updating shard failed: %s", strings.Join(msgs, ", "))
+	}
+
+	return nil
+}
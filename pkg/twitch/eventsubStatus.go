@@ -0,0 +1,127 @@
+package twitch
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collection of states an EventSub topic can be in
+const (
+	EventSubTopicStateFailed     = "failed"
+	EventSubTopicStatePending    = "pending"
+	EventSubTopicStateRetrying   = "retrying"
+	EventSubTopicStateSubscribed = "subscribed"
+)
+
+type (
+	// EventSubTopicStatus describes the subscription state of one
+	// topic within an EventSub client
+	EventSubTopicStatus struct {
+		Topic     string            `json:"topic"`
+		Version   string            `json:"version"`
+		Condition EventSubCondition `json:"condition"`
+		Optional  bool              `json:"optional"`
+		State     string            `json:"state"`
+		Cost      int64             `json:"cost"`
+		LastError string            `json:"last_error,omitempty"`
+		UpdatedAt time.Time         `json:"updated_at"`
+	}
+
+	// eventSubTopicTracker keeps track of the subscription state of
+	// all topics of an EventSub client
+	eventSubTopicTracker struct {
+		topics     map[string]*EventSubTopicStatus
+		topicsLock sync.RWMutex
+	}
+)
+
+// IsHealthy returns whether the topic is subscribed or still waiting
+// for its first subscription attempt
+func (e EventSubTopicStatus) IsHealthy() bool {
+	return e.State == EventSubTopicStateSubscribed || e.State == EventSubTopicStatePending
+}
+
+// TopicStatus returns a copy of the current state of all topics
+// sorted by topic name
+func (e *eventSubTopicTracker) TopicStatus() []EventSubTopicStatus {
+	e.topicsLock.RLock()
+	defer e.topicsLock.RUnlock()
+
+	out := make([]EventSubTopicStatus, 0, len(e.topics))
+	for _, t := range e.topics {
+		out = append(out, *t)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Topic != out[j].Topic {
+			return out[i].Topic < out[j].Topic
+		}
+		return out[i].Version < out[j].Version
+	})
+
+	return out
+}
+
+// resetTopics marks all given topics as pending
+func (e *eventSubTopicTracker) resetTopics(sts []eventSubSocketSubscriptionType) {
+	e.topicsLock.Lock()
+	defer e.topicsLock.Unlock()
+
+	e.topics = make(map[string]*EventSubTopicStatus, len(sts))
+	for _, st := range sts {
+		e.topics[e.topicKey(st)] = &EventSubTopicStatus{
+			Topic:     st.Event,
+			Version:   st.Version,
+			Condition: st.Condition,
+			Optional:  st.BackgroundRetry,
+			State:     EventSubTopicStatePending,
+			UpdatedAt: time.Now(),
+		}
+	}
+}
+
+// setTopicState updates the state of the given topic: on error the
+// topic is either marked as retrying or failed depending on whether
+// another attempt will be made
+func (e *eventSubTopicTracker) setTopicState(st eventSubSocketSubscriptionType, sub *eventSubSubscription, err error, willRetry bool) {
+	e.topicsLock.Lock()
+	defer e.topicsLock.Unlock()
+
+	if e.topics == nil {
+		e.topics = make(map[string]*EventSubTopicStatus)
+	}
+
+	t, ok := e.topics[e.topicKey(st)]
+	if !ok {
+		t = &EventSubTopicStatus{Topic: st.Event, Version: st.Version, Condition: st.Condition, Optional: st.BackgroundRetry}
+		e.topics[e.topicKey(st)] = t
+	}
+
+	t.UpdatedAt = time.Now()
+
+	switch {
+	case err == nil:
+		t.State = EventSubTopicStateSubscribed
+		t.LastError = ""
+		if sub != nil {
+			t.Cost = sub.Cost
+		}
+
+	case willRetry:
+		t.State = EventSubTopicStateRetrying
+		t.LastError = err.Error()
+
+	default:
+		t.State = EventSubTopicStateFailed
+		t.LastError = err.Error()
+	}
+}
+
+func (*eventSubTopicTracker) topicKey(st eventSubSocketSubscriptionType) string {
+	// Same topic might be subscribed with different conditions (i.e.
+	// incoming and outgoing raids) so the condition is part of the key
+	conHash, _ := st.Condition.Hash() //nolint:errcheck // Hashing a plain struct does not fail
+	return strings.Join([]string{st.Event, st.Version, conHash}, "/")
+}
@@ -0,0 +1,82 @@
+package twitch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSubTopicStatusIsHealthy(t *testing.T) {
+	for state, exp := range map[string]bool{
+		EventSubTopicStateFailed:     false,
+		EventSubTopicStatePending:    true,
+		EventSubTopicStateRetrying:   false,
+		EventSubTopicStateSubscribed: true,
+	} {
+		assert.Equal(t, exp, EventSubTopicStatus{State: state}.IsHealthy(), state)
+	}
+}
+
+func TestEventSubTopicTracker(t *testing.T) {
+	var (
+		follow   = eventSubSocketSubscriptionType{Event: EventSubEventTypeChannelFollow, Version: EventSubTopicVersion2, Condition: EventSubCondition{BroadcasterUserID: "123"}}
+		raidIn   = eventSubSocketSubscriptionType{Event: EventSubEventTypeChannelRaid, Version: EventSubTopicVersion1, Condition: EventSubCondition{ToBroadcasterUserID: "123"}, BackgroundRetry: true}
+		raidOut  = eventSubSocketSubscriptionType{Event: EventSubEventTypeChannelRaid, Version: EventSubTopicVersion1, Condition: EventSubCondition{FromBroadcasterUserID: "123"}, BackgroundRetry: true}
+		tracker  eventSubTopicTracker
+		stateMap = func() map[string]EventSubTopicStatus {
+			out := map[string]EventSubTopicStatus{}
+			for _, s := range tracker.TopicStatus() {
+				out[s.Topic+"/"+s.Condition.FromBroadcasterUserID] = s
+			}
+			return out
+		}
+	)
+
+	// Unknown topics are added when their state is set
+	tracker.setTopicState(follow, &eventSubSubscription{Cost: 1}, nil, false)
+	require.Len(t, tracker.TopicStatus(), 1)
+
+	// Reset replaces all topics and marks them pending
+	tracker.resetTopics([]eventSubSocketSubscriptionType{raidOut, follow, raidIn})
+
+	status := tracker.TopicStatus()
+	require.Len(t, status, 3)
+	assert.Equal(t, EventSubEventTypeChannelFollow, status[0].Topic)
+	for _, s := range status {
+		assert.Equal(t, EventSubTopicStatePending, s.State)
+		assert.Zero(t, s.Cost)
+	}
+
+	for _, step := range []struct {
+		st        eventSubSocketSubscriptionType
+		sub       *eventSubSubscription
+		err       error
+		willRetry bool
+		key       string
+		expState  string
+		expError  string
+		expCost   int64
+	}{
+		{follow, &eventSubSubscription{Cost: 1}, nil, false, EventSubEventTypeChannelFollow + "/", EventSubTopicStateSubscribed, "", 1},
+		{raidOut, nil, errors.New("forbidden"), true, EventSubEventTypeChannelRaid + "/123", EventSubTopicStateRetrying, "forbidden", 0},
+		{raidOut, nil, errors.New("still forbidden"), false, EventSubEventTypeChannelRaid + "/123", EventSubTopicStateFailed, "still forbidden", 0},
+		{raidOut, &eventSubSubscription{Cost: 0}, nil, false, EventSubEventTypeChannelRaid + "/123", EventSubTopicStateSubscribed, "", 0},
+		{raidIn, nil, errors.New("conflict"), false, EventSubEventTypeChannelRaid + "/", EventSubTopicStateFailed, "conflict", 0},
+	} {
+		tracker.setTopicState(step.st, step.sub, step.err, step.willRetry)
+
+		s := stateMap()[step.key]
+		assert.Equal(t, step.expState, s.State, step.key)
+		assert.Equal(t, step.expError, s.LastError, step.key)
+		assert.Equal(t, step.expCost, s.Cost, step.key)
+		assert.Equal(t, step.st.BackgroundRetry, s.Optional, step.key)
+	}
+
+	// Topics with same type but different conditions stay separate
+	states := stateMap()
+	assert.Len(t, states, 3)
+	assert.Equal(t, EventSubTopicStateSubscribed, states[EventSubEventTypeChannelRaid+"/123"].State)
+	assert.Equal(t, EventSubTopicStateFailed, states[EventSubEventTypeChannelRaid+"/"].State)
+}
@@ -0,0 +1,586 @@
+package twitch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Luzifer/go_helpers/backoff"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	eventsubWebhookHeaderMessageID        = "Twitch-Eventsub-Message-Id"
+	eventsubWebhookHeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	eventsubWebhookHeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	eventsubWebhookHeaderMessageType      = "Twitch-Eventsub-Message-Type"
+
+	eventsubWebhookMessageTypeNotification = "notification"
+	eventsubWebhookMessageTypeRevocation   = "revocation"
+	eventsubWebhookMessageTypeVerification = "webhook_callback_verification"
+
+	eventsubWebhookMaxBodySize   = 1024 * 1024      // Notifications are way smaller, just make sure not to read endless bodies
+	eventsubWebhookMaxMessageAge = 10 * time.Minute // Twitch recommends to reject messages older than 10 minutes
+	eventsubWebhookSecretMaxLen  = 100
+	eventsubWebhookSecretMinLen  = 10
+	eventsubWebhookSignaturePref = "sha256="
+)
+
+type (
+	// EventSubWebhookHandler receives EventSub messages through the
+	// webhook transport (optionally routed through a conduit) and
+	// dispatches them to the registered EventSubWebhookClients. It
+	// needs to be mounted as http.Handler at the callback URL.
+	EventSubWebhookHandler struct {
+		callbackURL string
+		logger      *logrus.Entry
+		secret      string
+		twitch      *Client
+
+		conduitID         string
+		conduitShardCount int64
+		conduitShardID    int64
+		useConduit        bool
+
+		clients map[*EventSubWebhookClient]struct{}
+		seen    map[string]time.Time
+		lock    sync.RWMutex
+	}
+
+	// EventSubWebhookHandlerOpt is a setter function to apply changes
+	// to the EventSubWebhookHandler on create
+	EventSubWebhookHandlerOpt func(*EventSubWebhookHandler)
+
+	// EventSubWebhookClient manages the subscriptions of one channel
+	// being delivered through the EventSubWebhookHandler. It has the
+	// same lifecycle as the EventSubSocketClient.
+	EventSubWebhookClient struct {
+		handler           *EventSubWebhookHandler
+		logger            *logrus.Entry
+		subscriptionIDs   map[string]struct{}
+		subscriptionTypes []eventSubSocketSubscriptionType
+		subscriptionsLock sync.Mutex
+
+		runCtx       context.Context //nolint:containedctx // internally held context for this client
+		runCtxCancel context.CancelFunc
+
+		eventSubTopicTracker
+	}
+
+	// EventSubWebhookClientOpt is a setter function to apply changes
+	// to the EventSubWebhookClient on create
+	EventSubWebhookClientOpt func(*EventSubWebhookClient)
+
+	eventSubWebhookPayloadVerification struct {
+		Challenge string `json:"challenge"`
+	}
+)
+
+// NewEventSubWebhookHandler creates a new EventSubWebhookHandler
+// using the given Client (needs to be able to acquire an app-access
+// token) to manage subscriptions for the given callback URL. The
+// secret is used to verify the messages are sent by Twitch.
+func NewEventSubWebhookHandler(tc *Client, callbackURL, secret string, opts ...EventSubWebhookHandlerOpt) (*EventSubWebhookHandler, error) {
+	h := &EventSubWebhookHandler{
+		callbackURL: callbackURL,
+		secret:      secret,
+		twitch:      tc,
+
+		clients: make(map[*EventSubWebhookClient]struct{}),
+		seen:    make(map[string]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.logger == nil {
+		discardLogger := logrus.New()
+		discardLogger.SetOutput(io.Discard)
+		h.logger = logrus.NewEntry(discardLogger)
+	}
+
+	if h.twitch == nil {
+		return nil, errors.New("no twitch-client configured")
+	}
+
+	if !strings.HasPrefix(h.callbackURL, "https://") {
+		return nil, errors.New("callback URL must use https")
+	}
+
+	if len(h.secret) < eventsubWebhookSecretMinLen || len(h.secret) > eventsubWebhookSecretMaxLen {
+		return nil, fmt.Errorf("secret must have %d-%d characters", eventsubWebhookSecretMinLen, eventsubWebhookSecretMaxLen)
+	}
+
+	if h.useConduit && (h.conduitShardID < 0 || h.conduitShardID >= h.conduitShardCount) {
+		return nil, errors.New("conduit shard ID must be lower than the shard count")
+	}
+
+	return h, nil
+}
+
+// WithWebhookConduit routes the subscriptions through a conduit:
+// if no conduitID is given, an existing conduit is used or a new one
+// with the given number of shards is created. This handler then
+// serves the shard with the given shardID.
+func WithWebhookConduit(conduitID string, shardCount, shardID int64) EventSubWebhookHandlerOpt {
+	return func(h *EventSubWebhookHandler) {
+		h.conduitID = conduitID
+		h.conduitShardCount = shardCount
+		h.conduitShardID = shardID
+		h.useConduit = true
+	}
+}
+
+// WithWebhookHandlerLogger configures the logger within the
+// EventSubWebhookHandler
+func WithWebhookHandlerLogger(logger *logrus.Entry) EventSubWebhookHandlerOpt {
+	return func(h *EventSubWebhookHandler) { h.logger = logger }
+}
+
+// ConduitID returns the ID of the conduit in use (empty if no
+// conduit is used or Init was not yet called)
+func (h *EventSubWebhookHandler) ConduitID() string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return h.conduitID
+}
+
+// Init prepares the conduit (if configured) to deliver messages to
+// the callback URL. As Twitch verifies the callback URL, the handler
+// must already be reachable when calling Init.
+func (h *EventSubWebhookHandler) Init(ctx context.Context) error {
+	if !h.useConduit {
+		return nil
+	}
+
+	conduitID := h.ConduitID()
+
+	if conduitID == "" {
+		conduits, err := h.twitch.getEventSubConduits(ctx)
+		if err != nil {
+			return fmt.Errorf("listing conduits: %w", err)
+		}
+
+		for _, conduit := range conduits {
+			if conduit.ShardCount > h.conduitShardID {
+				conduitID = conduit.ID
+				break
+			}
+		}
+
+		switch {
+		case conduitID != "":
+			// Found a conduit having our shard
+
+		case len(conduits) > 0:
+			// Creating another conduit would split the subscriptions
+			// between the conduits, the existing one needs to be used
+			return fmt.Errorf("existing conduit %s has no shard %d (shard_count %d)", conduits[0].ID, h.conduitShardID, conduits[0].ShardCount)
+
+		default:
+			conduit, err := h.twitch.createEventSubConduit(ctx, h.conduitShardCount)
+			if err != nil {
+				return fmt.Errorf("creating conduit: %w", err)
+			}
+
+			conduitID = conduit.ID
+		}
+	}
+
+	if err := h.twitch.updateEventSubConduitShard(ctx, conduitID, h.conduitShardID, eventSubTransport{
+		Method:   "webhook",
+		Callback: h.callbackURL,
+		Secret:   h.secret,
+	}); err != nil {
+		return fmt.Errorf("updating conduit shard: %w", err)
+	}
+
+	h.lock.Lock()
+	h.conduitID = conduitID
+	h.lock.Unlock()
+
+	h.logger.WithFields(logrus.Fields{
+		"conduit": conduitID,
+		"shard":   h.conduitShardID,
+	}).Debug("conduit shard configured")
+
+	return nil
+}
+
+// NewClient creates a new EventSubWebhookClient receiving its
+// messages through this handler and applies the given
+// EventSubWebhookClientOpts
+func (h *EventSubWebhookHandler) NewClient(opts ...EventSubWebhookClientOpt) *EventSubWebhookClient {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &EventSubWebhookClient{
+		handler:         h,
+		logger:          h.logger,
+		subscriptionIDs: make(map[string]struct{}),
+
+		runCtx:       ctx,
+		runCtxCancel: cancel,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.resetTopics(c.subscriptionTypes)
+
+	return c
+}
+
+// ServeHTTP implements the http.Handler interface and handles the
+// messages sent by Twitch
+func (h *EventSubWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, eventsubWebhookMaxBodySize))
+	if err != nil {
+		http.Error(w, "reading body", http.StatusBadRequest)
+		return
+	}
+
+	if !h.verifySignature(r.Header, body) {
+		h.logger.Warn("eventsub webhook received message with invalid signature")
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, r.Header.Get(eventsubWebhookHeaderMessageTimestamp))
+	if err != nil || time.Since(ts) > eventsubWebhookMaxMessageAge {
+		http.Error(w, "invalid or outdated timestamp", http.StatusBadRequest)
+		return
+	}
+
+	messageID := r.Header.Get(eventsubWebhookHeaderMessageID)
+	if !h.reserveMessage(messageID) {
+		// Twitch re-sent a message we already handled or are handling
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var handled bool
+	defer func() {
+		if !handled {
+			// Twitch will re-send messages which were not acknowledged
+			h.releaseMessage(messageID)
+		}
+	}()
+
+	switch r.Header.Get(eventsubWebhookHeaderMessageType) {
+	case eventsubWebhookMessageTypeNotification:
+		var payload eventSubSocketPayloadNotification
+		if err = json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		h.lock.RLock()
+		for c := range h.clients {
+			handleEventSubNotification(c.logger, c.subscriptionTypes, payload)
+		}
+		h.lock.RUnlock()
+
+		handled = true
+		w.WriteHeader(http.StatusNoContent)
+
+	case eventsubWebhookMessageTypeRevocation:
+		var payload eventSubSocketPayloadNotification
+		if err = json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		h.logger.WithFields(logrus.Fields{
+			"condition": payload.Subscription.Condition,
+			"reason":    payload.Subscription.Status,
+			"topic":     strings.Join([]string{payload.Subscription.Type, payload.Subscription.Version}, "/"),
+		}).Warn("eventsub subscription was revoked")
+
+		h.lock.RLock()
+		for c := range h.clients {
+			c.handleRevocation(payload)
+		}
+		h.lock.RUnlock()
+
+		handled = true
+		w.WriteHeader(http.StatusNoContent)
+
+	case eventsubWebhookMessageTypeVerification:
+		var payload eventSubWebhookPayloadVerification
+		if err = json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		handled = true
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		if _, err = w.Write([]byte(payload.Challenge)); err != nil {
+			h.logger.WithError(err).Error("writing challenge response")
+		}
+
+	default:
+		http.Error(w, "unknown message type", http.StatusBadRequest)
+	}
+}
+
+func (h *EventSubWebhookHandler) register(c *EventSubWebhookClient) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.clients[c] = struct{}{}
+}
+
+// releaseMessage removes the reservation of a message which could not
+// be handled so the re-sent message is processed again
+func (h *EventSubWebhookHandler) releaseMessage(messageID string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.seen, messageID)
+}
+
+// reserveMessage records the message as being handled and returns
+// false if it already was recorded. Check and record happen under the
+// same lock so concurrent re-deliveries are only handled once.
+func (h *EventSubWebhookHandler) reserveMessage(messageID string) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if _, ok := h.seen[messageID]; ok {
+		return false
+	}
+
+	for id, t := range h.seen {
+		if time.Since(t) > eventsubWebhookMaxMessageAge {
+			delete(h.seen, id)
+		}
+	}
+
+	h.seen[messageID] = time.Now()
+	return true
+}
+
+func (h *EventSubWebhookHandler) transport() eventSubTransport {
+	if h.useConduit {
+		return eventSubTransport{Method: "conduit", ConduitID: h.ConduitID()}
+	}
+
+	return eventSubTransport{Method: "webhook", Callback: h.callbackURL, Secret: h.secret}
+}
+
+func (h *EventSubWebhookHandler) unregister(c *EventSubWebhookClient) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.clients, c)
+}
+
+func (h *EventSubWebhookHandler) verifySignature(header http.Header, body []byte) bool {
+	sig, ok := strings.CutPrefix(header.Get(eventsubWebhookHeaderMessageSignature), eventsubWebhookSignaturePref)
+	if !ok {
+		return false
+	}
+
+	expected, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.secret))
+	mac.Write([]byte(header.Get(eventsubWebhookHeaderMessageID)))
+	mac.Write([]byte(header.Get(eventsubWebhookHeaderMessageTimestamp)))
+	mac.Write(body)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// WithWebhookClientLogger configures the logger within the
+// EventSubWebhookClient
+func WithWebhookClientLogger(logger *logrus.Entry) EventSubWebhookClientOpt {
+	return func(e *EventSubWebhookClient) { e.logger = logger }
+}
+
+// WithWebhookMustSubscribe adds a topic to the subscriptions to be
+// done on start
+func WithWebhookMustSubscribe(event, version string, condition EventSubCondition, callback func(json.RawMessage) error) EventSubWebhookClientOpt {
+	if version == "" {
+		version = EventSubTopicVersion1
+	}
+
+	return func(e *EventSubWebhookClient) {
+		e.subscriptionTypes = append(e.subscriptionTypes, eventSubSocketSubscriptionType{
+			Event:     event,
+			Version:   version,
+			Condition: condition,
+			Callback:  callback,
+		})
+	}
+}
+
+// WithWebhookRetryBackgroundSubscribe adds a topic to the
+// subscriptions to be done on start async
+func WithWebhookRetryBackgroundSubscribe(event, version string, condition EventSubCondition, callback func(json.RawMessage) error) EventSubWebhookClientOpt {
+	if version == "" {
+		version = EventSubTopicVersion1
+	}
+
+	return func(e *EventSubWebhookClient) {
+		e.subscriptionTypes = append(e.subscriptionTypes, eventSubSocketSubscriptionType{
+			Event:           event,
+			Version:         version,
+			Condition:       condition,
+			Callback:        callback,
+			BackgroundRetry: true,
+		})
+	}
+}
+
+// Close cancels the contained context and brings the
+// EventSubWebhookClient to a halt
+func (e *EventSubWebhookClient) Close() { e.runCtxCancel() }
+
+// Run subscribes to all topics and blocks until the client is closed.
+// On close all subscriptions are removed unless they are delivered
+// through a conduit as those are shared by all shards.
+func (e *EventSubWebhookClient) Run() error {
+	e.handler.register(e)
+	defer e.handler.unregister(e)
+	defer e.unsubscribeAll()
+
+	if err := e.subscribeAll(); err != nil {
+		return err
+	}
+
+	<-e.runCtx.Done()
+	return nil
+}
+
+// handleRevocation marks the topics of the revoked subscription as
+// failed and tries to subscribe them again in the background
+func (e *EventSubWebhookClient) handleRevocation(payload eventSubSocketPayloadNotification) {
+	e.subscriptionsLock.Lock()
+	delete(e.subscriptionIDs, payload.Subscription.ID)
+	e.subscriptionsLock.Unlock()
+
+	for _, st := range e.subscriptionTypes {
+		if st.Event != payload.Subscription.Type || st.Version != payload.Subscription.Version || !reflect.DeepEqual(st.Condition, payload.Subscription.Condition) {
+			continue
+		}
+
+		e.setTopicState(st, nil, fmt.Errorf("subscription revoked: %s", payload.Subscription.Status), true)
+		go e.retryBackgroundSubscribe(st)
+	}
+}
+
+func (e *EventSubWebhookClient) retryBackgroundSubscribe(st eventSubSocketSubscriptionType) {
+	err := backoff.NewBackoff().
+		WithMaxIterationTime(retrySubscribeMaxWait).
+		WithMaxTotalTime(retrySubscribeMaxTotal).
+		WithMinIterationTime(retrySubscribeMinWait).
+		Retry(func() error {
+			if err := e.runCtx.Err(); err != nil {
+				// Our run-context was cancelled, stop retrying to subscribe
+				// to topics as this client was closed
+				return backoff.NewErrCannotRetry(err)
+			}
+
+			return e.subscribe(st)
+		})
+	if err != nil {
+		e.setTopicState(st, nil, err, false)
+		e.logger.
+			WithError(err).
+			WithField("topic", strings.Join([]string{st.Event, st.Version}, "/")).
+			Error("gave up retrying to subscribe")
+	}
+}
+
+func (e *EventSubWebhookClient) subscribe(st eventSubSocketSubscriptionType) error {
+	logger := e.logger.
+		WithField("topic", strings.Join([]string{st.Event, st.Version}, "/"))
+
+	sub, err := e.handler.twitch.createEventSubSubscription(e.runCtx, AuthTypeAppAccessToken, eventSubSubscription{
+		Type:      st.Event,
+		Version:   st.Version,
+		Condition: st.Condition,
+		Transport: e.handler.transport(),
+	})
+	e.setTopicState(st, sub, err, st.BackgroundRetry)
+	if err != nil {
+		logger.WithError(err).Debug("subscribing to topic")
+		return fmt.Errorf("subscribing to %s/%s: %w", st.Event, st.Version, err)
+	}
+
+	if e.runCtx.Err() != nil {
+		if e.handler.useConduit {
+			// Subscription is shared with all other shards
+			return nil
+		}
+
+		// Client was closed while subscribing, do not leave the
+		// subscription behind
+		if err = e.handler.twitch.deleteEventSubSubscription(context.Background(), AuthTypeAppAccessToken, sub.ID); err != nil {
+			return backoff.NewErrCannotRetry(fmt.Errorf("removing subscription of closed client: %w", err))
+		}
+		return nil
+	}
+
+	e.subscriptionsLock.Lock()
+	e.subscriptionIDs[sub.ID] = struct{}{}
+	e.subscriptionsLock.Unlock()
+
+	logger.Debug("subscribed to topic")
+	return nil
+}
+
+func (e *EventSubWebhookClient) subscribeAll() (err error) {
+	for i := range e.subscriptionTypes {
+		st := e.subscriptionTypes[i]
+
+		if st.BackgroundRetry {
+			go e.retryBackgroundSubscribe(st)
+			continue
+		}
+
+		if err = e.subscribe(st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *EventSubWebhookClient) unsubscribeAll() {
+	if e.handler.useConduit {
+		// Subscriptions on a conduit are shared by all shards and
+		// might be needed by other instances still running
+		return
+	}
+
+	e.subscriptionsLock.Lock()
+	defer e.subscriptionsLock.Unlock()
+
+	for id := range e.subscriptionIDs {
+		// The run-context is most likely already cancelled
+		if err := e.handler.twitch.deleteEventSubSubscription(context.Background(), AuthTypeAppAccessToken, id); err != nil {
+			e.logger.WithError(err).WithField("id", id).Error("removing eventsub subscription")
+			continue
+		}
+
+		delete(e.subscriptionIDs, id)
+	}
+}
@@ -0,0 +1,110 @@
+package twitch
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "verysecretsecret"
+
+func TestEventSubWebhookHandler(t *testing.T) {
+	h, err := NewEventSubWebhookHandler(New("id", "secret", "", ""), "https://example.com/eventsub", testWebhookSecret)
+	require.NoError(t, err)
+
+	var received []string
+	c := h.NewClient(WithWebhookMustSubscribe(EventSubEventTypeChannelFollow, EventSubTopicVersion2, EventSubCondition{BroadcasterUserID: "123"}, func(m json.RawMessage) error {
+		received = append(received, string(m))
+		return nil
+	}))
+	h.register(c)
+
+	notification := `{"subscription":{"type":"channel.follow","version":"2","condition":{"broadcaster_user_id":"123"}},"event":{"user_login":"amy"}}`
+
+	// Callback verification is answered with the challenge
+	resp := sendSignedWebhook(t, h, "msg-1", eventsubWebhookMessageTypeVerification, `{"challenge":"pogchamp-kappa-360noscope-vohiyo"}`, testWebhookSecret)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "pogchamp-kappa-360noscope-vohiyo", resp.Body.String())
+
+	// Notification is passed to the callback
+	resp = sendSignedWebhook(t, h, "msg-2", eventsubWebhookMessageTypeNotification, notification, testWebhookSecret)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, []string{`{"user_login":"amy"}`}, received)
+
+	// Re-delivery of the same message is ignored
+	resp = sendSignedWebhook(t, h, "msg-2", eventsubWebhookMessageTypeNotification, notification, testWebhookSecret)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Len(t, received, 1)
+
+	// Messages signed with a different secret are rejected
+	resp = sendSignedWebhook(t, h, "msg-3", eventsubWebhookMessageTypeNotification, notification, "someothersecret")
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Len(t, received, 1)
+
+	// Notifications for other conditions are not passed to the callback
+	resp = sendSignedWebhook(t, h, "msg-4", eventsubWebhookMessageTypeNotification, strings.ReplaceAll(notification, "123", "456"), testWebhookSecret)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Len(t, received, 1)
+
+	// Messages failing to be handled are accepted when re-sent
+	resp = sendSignedWebhook(t, h, "msg-5", eventsubWebhookMessageTypeNotification, "{", testWebhookSecret)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = sendSignedWebhook(t, h, "msg-5", eventsubWebhookMessageTypeNotification, notification, testWebhookSecret)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Len(t, received, 2)
+
+	// Concurrent re-deliveries of the same message are handled once
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sendSignedWebhook(t, h, "msg-6", eventsubWebhookMessageTypeNotification, notification, testWebhookSecret)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, received, 3)
+}
+
+func TestNewEventSubWebhookHandlerValidation(t *testing.T) {
+	tc := New("id", "secret", "", "")
+
+	_, err := NewEventSubWebhookHandler(tc, "http://example.com/eventsub", testWebhookSecret)
+	assert.Error(t, err, "non-https callback")
+
+	_, err = NewEventSubWebhookHandler(tc, "https://example.com/eventsub", "short")
+	assert.Error(t, err, "short secret")
+
+	_, err = NewEventSubWebhookHandler(tc, "https://example.com/eventsub", testWebhookSecret, WithWebhookConduit("", 2, 2))
+	assert.Error(t, err, "shard ID out of range")
+}
+
+func sendSignedWebhook(t *testing.T, h http.Handler, msgID, msgType, body, secret string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(msgID + ts + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/eventsub", strings.NewReader(body))
+	req.Header.Set(eventsubWebhookHeaderMessageID, msgID)
+	req.Header.Set(eventsubWebhookHeaderMessageSignature, eventsubWebhookSignaturePref+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set(eventsubWebhookHeaderMessageTimestamp, ts)
+	req.Header.Set(eventsubWebhookHeaderMessageType, msgType)
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, req)
+
+	return resp
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net"
-	"reflect"
 	"strings"
 	"time"
 
@@ -62,6 +61,8 @@ type (
 
 		runCtx       context.Context //nolint:containedctx // internally held context for this client
 		runCtxCancel context.CancelFunc
+
+		eventSubTopicTracker
 	}
 
 	// EventSubSocketClientOpt is a setter function to apply changes to
@@ -142,6 +143,8 @@ func NewEventSubSocketClient(opts ...EventSubSocketClientOpt) (*EventSubSocketCl
 		return nil, errors.New("no twitch-client configured")
 	}
 
+	c.resetTopics(c.subscriptionTypes)
+
 	return c, nil
 }
 
@@ -313,20 +316,7 @@ func (e *EventSubSocketClient) handleNotificationMessage(msg eventSubSocketMessa
 		return fmt.Errorf("unmarshalling notification: %w", err)
 	}
 
-	for _, st := range e.subscriptionTypes {
-		if st.Event != payload.Subscription.Type || st.Version != payload.Subscription.Version || !reflect.DeepEqual(st.Condition, payload.Subscription.Condition) {
-			continue
-		}
-
-		if err := st.Callback(payload.Event); err != nil {
-			e.logger.WithError(err).WithFields(logrus.Fields{
-				"condition": st.Condition,
-				"event":     st.Event,
-				"version":   st.Version,
-			}).Error("callback caused error")
-		}
-	}
-
+	handleEventSubNotification(e.logger, e.subscriptionTypes, payload)
 	return nil
 }
 
@@ -468,6 +458,7 @@ func (e *EventSubSocketClient) retryBackgroundSubscribe(st eventSubSocketSubscri
 			return e.subscribe(st)
 		})
 	if err != nil {
+		e.setTopicState(st, nil, err, false)
 		e.logger.
 			WithError(err).
 			WithField("topic", strings.Join([]string{st.Event, st.Version}, "/")).
@@ -479,7 +470,7 @@ func (e *EventSubSocketClient) subscribe(st eventSubSocketSubscriptionType) erro
 	logger := e.logger.
 		WithField("topic", strings.Join([]string{st.Event, st.Version}, "/"))
 
-	if _, err := e.twitch.createEventSubSubscriptionWebsocket(context.Background(), eventSubSubscription{
+	sub, err := e.twitch.createEventSubSubscriptionWebsocket(context.Background(), eventSubSubscription{
 		Type:      st.Event,
 		Version:   st.Version,
 		Condition: st.Condition,
@@ -487,7 +478,9 @@ func (e *EventSubSocketClient) subscribe(st eventSubSocketSubscriptionType) erro
 			Method:    "websocket",
 			SessionID: e.socketID,
 		},
-	}); err != nil {
+	})
+	e.setTopicState(st, sub, err, st.BackgroundRetry)
+	if err != nil {
 		logger.WithError(err).Debug("subscribing to topic")
 		return fmt.Errorf("subscribing to %s/%s: %w", st.Event, st.Version, err)
 	}
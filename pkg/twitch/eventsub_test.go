@@ -0,0 +1,70 @@
+package twitch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSubEventChannelModerateTarget(t *testing.T) {
+	for name, tc := range map[string]struct {
+		payload   string
+		expTarget string
+	}{
+		"ban":                {`{"action":"ban","ban":{"user_login":"amy","reason":"spam"}}`, "amy"},
+		"timeout":            {`{"action":"timeout","timeout":{"user_login":"bob","expires_at":"2026-01-01T00:00:00Z"}}`, "bob"},
+		"delete":             {`{"action":"delete","delete":{"user_login":"carl","message_id":"m1"}}`, "carl"},
+		"raid":               {`{"action":"raid","raid":{"user_login":"dora","viewer_count":12}}`, "dora"},
+		"warn":               {`{"action":"warn","warn":{"user_login":"eve","chat_rules_cited":["No spam"]}}`, "eve"},
+		"unban request":      {`{"action":"approve_unban_request","unban_request":{"user_login":"fred","is_approved":true}}`, "fred"},
+		"shared chat delete": {`{"action":"shared_chat_delete","shared_chat_delete":{"user_login":"gina"}}`, "gina"},
+		"slow":               {`{"action":"slow","slow":{"wait_time_seconds":10}}`, ""},
+		"blocked term":       {`{"action":"add_blocked_term","automod_terms":{"action":"add","list":"blocked","terms":["foo"]}}`, ""},
+		"clear":              {`{"action":"clear"}`, ""},
+	} {
+		var payload EventSubEventChannelModerate
+		require.NoError(t, json.Unmarshal([]byte(tc.payload), &payload), name)
+
+		target := payload.Target()
+		if tc.expTarget == "" {
+			assert.Nil(t, target, name)
+			continue
+		}
+
+		require.NotNil(t, target, name)
+		assert.Equal(t, tc.expTarget, target.UserLogin, name)
+	}
+}
+
+func TestEventSubEventAutomodMessageHoldFoundTerms(t *testing.T) {
+	for name, tc := range map[string]struct {
+		payload  string
+		expTerms []string
+	}{
+		"automod reason": {
+			`{"message":{"text":"you are bad"},"reason":"automod","automod":{"category":"aggressive","level":2}}`,
+			nil,
+		},
+		"single term": {
+			`{"message":{"text":"buy followers now"},"reason":"blocked_term","blocked_term":{"terms_found":[{"term_id":"t1","boundary":{"start_pos":4,"end_pos":12}}]}}`,
+			[]string{"followers"},
+		},
+		"multibyte text": {
+			`{"message":{"text":"😀 Spam here"},"reason":"blocked_term","blocked_term":{"terms_found":[{"term_id":"t1","boundary":{"start_pos":2,"end_pos":5}}]}}`,
+			[]string{"Spam"},
+		},
+		"invalid boundaries": {
+			`{"message":{"text":"short"},"reason":"blocked_term","blocked_term":{"terms_found":[` +
+				`{"term_id":"t1","boundary":{"start_pos":2,"end_pos":10}},` +
+				`{"term_id":"t2","boundary":{"start_pos":3,"end_pos":1}},` +
+				`{"term_id":"t3","boundary":{"start_pos":0,"end_pos":1}}]}}`,
+			[]string{"sh"},
+		},
+	} {
+		var payload EventSubEventAutomodMessageHold
+		require.NoError(t, json.Unmarshal([]byte(tc.payload), &payload), name)
+		assert.Equal(t, tc.expTerms, payload.FoundTerms(), name)
+	}
+}
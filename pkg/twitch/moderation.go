@@ -16,6 +16,7 @@ import (
 const (
 	errMessageAlreadyBanned = "The user specified in the user_id field is already banned."
 	maxTimeoutDuration      = 1209600 * time.Second
+	maxWarnReasonLength     = 500
 )
 
 // BanUser bans or timeouts a user in the given channel. Setting the
@@ -130,6 +131,82 @@ func (c *Client) DeleteMessage(ctx context.Context, channel, messageID string) e
 	return nil
 }
 
+// ManageHeldAutomodMessage approves or denies a message held by
+// AutoMod for review
+func (c *Client) ManageHeldAutomodMessage(ctx context.Context, messageID string, allow bool) error {
+	botID, _, err := c.GetAuthorizedUser(ctx)
+	if err != nil {
+		return fmt.Errorf("getting bot user-id: %w", err)
+	}
+
+	action := "DENY"
+	if allow {
+		action = "ALLOW"
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(map[string]string{
+		"action":  action,
+		"msg_id":  messageID,
+		"user_id": botID,
+	}); err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodPost,
+		OKStatus: http.StatusNoContent,
+		Body:     body,
+		URL:      "https://api.twitch.tv/helix/moderation/automod/message",
+	}); err != nil {
+		return fmt.Errorf("executing automod request for %q: %w", messageID, err)
+	}
+
+	return nil
+}
+
+// ResolveUnbanRequest approves or denies the unban request with the
+// given ID in the channel. The resolution text is optional and shown
+// to the user.
+func (c *Client) ResolveUnbanRequest(ctx context.Context, channel, requestID string, approve bool, resolutionText string) error {
+	botID, _, err := c.GetAuthorizedUser(ctx)
+	if err != nil {
+		return fmt.Errorf("getting bot user-id: %w", err)
+	}
+
+	channelID, err := c.GetIDForUsername(ctx, strings.TrimLeft(channel, "#@"))
+	if err != nil {
+		return fmt.Errorf("getting channel user-id: %w", err)
+	}
+
+	params := make(url.Values)
+	params.Set("broadcaster_id", channelID)
+	params.Set("moderator_id", botID)
+	params.Set("unban_request_id", requestID)
+	params.Set("status", "denied")
+	if approve {
+		params.Set("status", "approved")
+	}
+	if resolutionText != "" {
+		params.Set("resolution_text", resolutionText)
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodPatch,
+		OKStatus: http.StatusOK,
+		URL: fmt.Sprintf(
+			"https://api.twitch.tv/helix/moderation/unban_requests?%s",
+			params.Encode(),
+		),
+	}); err != nil {
+		return fmt.Errorf("executing resolve request for %q: %w", requestID, err)
+	}
+
+	return nil
+}
+
 // UnbanUser removes a timeout or ban given to the user in the channel
 func (c *Client) UnbanUser(ctx context.Context, channel, username string) error {
 	botID, _, err := c.GetAuthorizedUser(ctx)
@@ -196,3 +273,59 @@ func (c *Client) UpdateShieldMode(ctx context.Context, channel string, enable bo
 
 	return nil
 }
+
+// WarnUser sends a warning to the user in the given channel the user
+// needs to acknowledge before being able to chat again. The reason is
+// required and must not exceed 500 characters.
+func (c *Client) WarnUser(ctx context.Context, channel, username, reason string) error {
+	var payload struct {
+		Data struct {
+			Reason string `json:"reason"`
+			UserID string `json:"user_id"`
+		} `json:"data"`
+	}
+
+	if reason == "" {
+		return errors.New("warning reason must not be empty")
+	}
+
+	if len([]rune(reason)) > maxWarnReasonLength {
+		return errors.New("warning reason exceeds maximum length")
+	}
+
+	payload.Data.Reason = reason
+
+	botID, _, err := c.GetAuthorizedUser(ctx)
+	if err != nil {
+		return fmt.Errorf("getting bot user-id: %w", err)
+	}
+
+	channelID, err := c.GetIDForUsername(ctx, strings.TrimLeft(channel, "#@"))
+	if err != nil {
+		return fmt.Errorf("getting channel user-id: %w", err)
+	}
+
+	if payload.Data.UserID, err = c.GetIDForUsername(ctx, strings.TrimLeft(username, "@")); err != nil {
+		return fmt.Errorf("getting target user-id: %w", err)
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(payload); err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	if err = c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodPost,
+		OKStatus: http.StatusOK,
+		Body:     body,
+		URL: fmt.Sprintf(
+			"https://api.twitch.tv/helix/moderation/warnings?broadcaster_id=%s&moderator_id=%s",
+			channelID, botID,
+		),
+	}); err != nil {
+		return fmt.Errorf("executing warn request for %q in %q: %w", username, channel, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageHeldAutomodMessage(t *testing.T) {
+	var reqBody map[string]string
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/helix/users":
+			_, err := w.Write([]byte(`{"data":[{"id":"42","login":"bot"}]}`))
+			assert.NoError(t, err)
+
+		case "/helix/moderation/automod/message":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	for allow, expAction := range map[bool]string{true: "ALLOW", false: "DENY"} {
+		require.NoError(t, c.ManageHeldAutomodMessage(context.Background(), "msg1", allow))
+		assert.Equal(t, map[string]string{"action": expAction, "msg_id": "msg1", "user_id": "42"}, reqBody)
+	}
+}
+
+func TestWarnUser(t *testing.T) {
+	var (
+		reqQuery url.Values
+		reqBody  map[string]map[string]string
+	)
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/helix/users":
+			_, err := w.Write([]byte(`{"data":[{"id":"42","login":"bot"}]}`))
+			assert.NoError(t, err)
+
+		case "/helix/moderation/warnings":
+			assert.Equal(t, http.MethodPost, r.Method)
+			reqQuery = r.URL.Query()
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+			_, err := w.Write([]byte(`{"data":[]}`))
+			assert.NoError(t, err)
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+	c.apiCache.Set([]string{"idForUsername", "amy"}, time.Hour, "7")
+
+	require.NoError(t, c.WarnUser(context.Background(), "#testchannel", "@amy", "be nice"))
+	assert.Equal(t, url.Values{"broadcaster_id": {"123"}, "moderator_id": {"42"}}, reqQuery)
+	assert.Equal(t, map[string]map[string]string{"data": {"reason": "be nice", "user_id": "7"}}, reqBody)
+
+	for name, reason := range map[string]string{
+		"empty reason":    "",
+		"too long reason": strings.Repeat("ä", maxWarnReasonLength+1),
+	} {
+		reqQuery = nil
+		assert.Error(t, c.WarnUser(context.Background(), "#testchannel", "amy", reason), name)
+		assert.Nil(t, reqQuery, name)
+	}
+
+	// Length is counted in characters, not bytes
+	require.NoError(t, c.WarnUser(context.Background(), "#testchannel", "amy", strings.Repeat("ä", maxWarnReasonLength)))
+}
+
+func TestResolveUnbanRequest(t *testing.T) {
+	var reqQuery url.Values
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/helix/users":
+			_, err := w.Write([]byte(`{"data":[{"id":"42","login":"bot"}]}`))
+			assert.NoError(t, err)
+
+		case "/helix/moderation/unban_requests":
+			assert.Equal(t, http.MethodPatch, r.Method)
+			reqQuery = r.URL.Query()
+			_, err := w.Write([]byte(`{"data":[]}`))
+			assert.NoError(t, err)
+
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		approve    bool
+		resolution string
+		expQuery   url.Values
+	}{
+		"approve with text": {true, "welcome back", url.Values{
+			"broadcaster_id": {"123"}, "moderator_id": {"42"}, "unban_request_id": {"req1"}, "status": {"approved"}, "resolution_text": {"welcome back"},
+		}},
+		"deny without text": {false, "", url.Values{
+			"broadcaster_id": {"123"}, "moderator_id": {"42"}, "unban_request_id": {"req1"}, "status": {"denied"},
+		}},
+	} {
+		require.NoError(t, c.ResolveUnbanRequest(context.Background(), "#testchannel", "req1", tc.approve, tc.resolution), name)
+		assert.Equal(t, tc.expQuery, reqQuery, name)
+	}
+}
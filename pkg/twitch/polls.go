@@ -1,7 +1,9 @@
 package twitch
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -10,6 +12,14 @@ import (
 
 const pollCacheTimeout = 10 * time.Second // Cache polls for a short moment to prevent multiple requests in one template
 
+// Collection of known poll states
+const (
+	PollStatusActive     = "ACTIVE"
+	PollStatusArchived   = "ARCHIVED"
+	PollStatusCompleted  = "COMPLETED"
+	PollStatusTerminated = "TERMINATED"
+)
+
 type (
 	// PollInfo contains information about a Twitch poll
 	PollInfo struct {
@@ -33,6 +43,74 @@ type (
 	}
 )
 
+// CreatePoll starts a new poll in the given channel with the given
+// choices (2-5) and duration (15s-30m). If channelPointsPerVote is
+// greater than zero, viewers can cast additional votes using channel
+// points.
+func (c *Client) CreatePoll(ctx context.Context, channel, title string, choices []string, duration time.Duration, channelPointsPerVote int64) (*PollInfo, error) {
+	id, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for username: %w", err)
+	}
+
+	type choice struct {
+		Title string `json:"title"`
+	}
+
+	payload := struct {
+		BroadcasterID              string   `json:"broadcaster_id"`
+		Title                      string   `json:"title"`
+		Choices                    []choice `json:"choices"`
+		Duration                   int64    `json:"duration"`
+		ChannelPointsVotingEnabled bool     `json:"channel_points_voting_enabled"`
+		ChannelPointsPerVote       int64    `json:"channel_points_per_vote,omitempty"`
+	}{
+		BroadcasterID:              id,
+		Title:                      title,
+		Duration:                   int64(duration / time.Second),
+		ChannelPointsVotingEnabled: channelPointsPerVote > 0,
+		ChannelPointsPerVote:       channelPointsPerVote,
+	}
+
+	for _, ch := range choices {
+		payload.Choices = append(payload.Choices, choice{Title: ch})
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	return c.executePollRequest(ctx, channel, http.MethodPost, body)
+}
+
+// EndPoll ends the poll with the given ID. The status must either be
+// PollStatusTerminated (results stay visible) or PollStatusArchived
+// (results are hidden).
+func (c *Client) EndPoll(ctx context.Context, channel, pollID, status string) (*PollInfo, error) {
+	id, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for username: %w", err)
+	}
+
+	payload := struct {
+		BroadcasterID string `json:"broadcaster_id"`
+		ID            string `json:"id"`
+		Status        string `json:"status"`
+	}{
+		BroadcasterID: id,
+		ID:            pollID,
+		Status:        status,
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	return c.executePollRequest(ctx, channel, http.MethodPatch, body)
+}
+
 // GetLatestPoll returns the lastest (active or past) poll inside the
 // given channel
 func (c *Client) GetLatestPoll(ctx context.Context, channel string) (*PollInfo, error) {
@@ -68,3 +146,30 @@ func (c *Client) GetLatestPoll(ctx context.Context, channel string) (*PollInfo,
 
 	return payload.Data[0], nil
 }
+
+func (c *Client) executePollRequest(ctx context.Context, channel, method string, body *bytes.Buffer) (*PollInfo, error) {
+	var payload struct {
+		Data []*PollInfo `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Body:     body,
+		Method:   method,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      "https://api.twitch.tv/helix/polls",
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Data) < 1 {
+		return nil, errors.New("no poll returned")
+	}
+
+	// Replace the cached poll so subsequent calls do not see an
+	// outdated state
+	c.apiCache.Set([]string{"getLatestPoll", channel}, pollCacheTimeout, payload.Data[0])
+
+	return payload.Data[0], nil
+}
@@ -0,0 +1,74 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollRequests(t *testing.T) {
+	var (
+		reqMethod string
+		reqBody   map[string]any
+	)
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/helix/polls", r.URL.Path)
+
+		reqMethod, reqBody = r.Method, nil
+		if r.Method != http.MethodGet {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"id": "poll", "status": PollStatusActive, "title": "Next game?"}},
+		}))
+	})
+
+	for name, tc := range map[string]struct {
+		pointsPerVote int64
+		expBody       map[string]any
+	}{
+		"without channel points": {0, map[string]any{
+			"broadcaster_id":                "123",
+			"title":                         "Next game?",
+			"choices":                       []any{map[string]any{"title": "A"}, map[string]any{"title": "B"}},
+			"duration":                      float64(60),
+			"channel_points_voting_enabled": false,
+		}},
+		"with channel points": {100, map[string]any{
+			"broadcaster_id":                "123",
+			"title":                         "Next game?",
+			"choices":                       []any{map[string]any{"title": "A"}, map[string]any{"title": "B"}},
+			"duration":                      float64(60),
+			"channel_points_voting_enabled": true,
+			"channel_points_per_vote":       float64(100),
+		}},
+	} {
+		p, err := c.CreatePoll(context.Background(), "testchannel", "Next game?", []string{"A", "B"}, time.Minute, tc.pointsPerVote)
+		require.NoError(t, err, name)
+		assert.Equal(t, "poll", p.ID, name)
+		assert.Equal(t, http.MethodPost, reqMethod, name)
+		assert.Equal(t, tc.expBody, reqBody, name)
+	}
+
+	for _, status := range []string{PollStatusTerminated, PollStatusArchived} {
+		_, err := c.EndPoll(context.Background(), "testchannel", "poll", status)
+		require.NoError(t, err, status)
+		assert.Equal(t, http.MethodPatch, reqMethod, status)
+		assert.Equal(t, map[string]any{"broadcaster_id": "123", "id": "poll", "status": status}, reqBody, status)
+	}
+
+	// Latest poll is served from the cache updated by the last request
+	reqMethod = ""
+	p, err := c.GetLatestPoll(context.Background(), "testchannel")
+	require.NoError(t, err)
+	assert.Equal(t, "poll", p.ID)
+	assert.Empty(t, reqMethod)
+}
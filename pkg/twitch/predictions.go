@@ -0,0 +1,186 @@
+package twitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const predictionCacheTimeout = 10 * time.Second // Cache predictions for a short moment to prevent multiple requests in one template
+
+// Collection of known prediction states
+const (
+	PredictionStatusActive   = "ACTIVE"
+	PredictionStatusCanceled = "CANCELED"
+	PredictionStatusLocked   = "LOCKED"
+	PredictionStatusResolved = "RESOLVED"
+)
+
+type (
+	// PredictionInfo contains information about a Twitch prediction
+	PredictionInfo struct {
+		ID               string              `json:"id"`
+		BroadcasterID    string              `json:"broadcaster_id"`
+		BroadcasterName  string              `json:"broadcaster_name"`
+		BroadcasterLogin string              `json:"broadcaster_login"`
+		Title            string              `json:"title"`
+		WinningOutcomeID *string             `json:"winning_outcome_id"`
+		Outcomes         []PredictionOutcome `json:"outcomes"`
+		PredictionWindow int                 `json:"prediction_window"`
+		Status           string              `json:"status"`
+		CreatedAt        time.Time           `json:"created_at"`
+		EndedAt          *time.Time          `json:"ended_at"`
+		LockedAt         *time.Time          `json:"locked_at"`
+	}
+
+	// PredictionOutcome contains information about one of the
+	// possible outcomes of a prediction
+	PredictionOutcome struct {
+		ID            string `json:"id"`
+		Title         string `json:"title"`
+		Users         int    `json:"users"`
+		ChannelPoints int    `json:"channel_points"`
+		TopPredictors []struct {
+			UserID            string `json:"user_id"`
+			UserName          string `json:"user_name"`
+			UserLogin         string `json:"user_login"`
+			ChannelPointsUsed int    `json:"channel_points_used"`
+			ChannelPointsWon  int    `json:"channel_points_won"`
+		} `json:"top_predictors"`
+		Color string `json:"color"`
+	}
+)
+
+// CreatePrediction starts a new prediction in the given channel with
+// the given outcomes (2-10) and prediction window (30s-30m)
+func (c *Client) CreatePrediction(ctx context.Context, channel, title string, outcomes []string, window time.Duration) (*PredictionInfo, error) {
+	id, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for username: %w", err)
+	}
+
+	type outcome struct {
+		Title string `json:"title"`
+	}
+
+	payload := struct {
+		BroadcasterID    string    `json:"broadcaster_id"`
+		Title            string    `json:"title"`
+		Outcomes         []outcome `json:"outcomes"`
+		PredictionWindow int64     `json:"prediction_window"`
+	}{
+		BroadcasterID:    id,
+		Title:            title,
+		PredictionWindow: int64(window / time.Second),
+	}
+
+	for _, o := range outcomes {
+		payload.Outcomes = append(payload.Outcomes, outcome{Title: o})
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	return c.executePredictionRequest(ctx, channel, http.MethodPost, body)
+}
+
+// EndPrediction locks, resolves or cancels the prediction with the
+// given ID. The winningOutcomeID is required when resolving the
+// prediction and ignored otherwise.
+func (c *Client) EndPrediction(ctx context.Context, channel, predictionID, status, winningOutcomeID string) (*PredictionInfo, error) {
+	id, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for username: %w", err)
+	}
+
+	payload := struct {
+		BroadcasterID    string `json:"broadcaster_id"`
+		ID               string `json:"id"`
+		Status           string `json:"status"`
+		WinningOutcomeID string `json:"winning_outcome_id,omitempty"`
+	}{
+		BroadcasterID: id,
+		ID:            predictionID,
+		Status:        status,
+	}
+
+	if status == PredictionStatusResolved {
+		payload.WinningOutcomeID = winningOutcomeID
+	}
+
+	body := new(bytes.Buffer)
+	if err = json.NewEncoder(body).Encode(payload); err != nil {
+		return nil, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	return c.executePredictionRequest(ctx, channel, http.MethodPatch, body)
+}
+
+// GetLatestPrediction returns the lastest (active or past) prediction
+// inside the given channel
+func (c *Client) GetLatestPrediction(ctx context.Context, channel string) (*PredictionInfo, error) {
+	cacheKey := []string{"getLatestPrediction", channel}
+	if prediction := c.apiCache.Get(cacheKey); prediction != nil {
+		return prediction.(*PredictionInfo), nil
+	}
+
+	id, err := c.GetIDForUsername(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("getting ID for username: %w", err)
+	}
+
+	var payload struct {
+		Data []*PredictionInfo `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Method:   http.MethodGet,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      fmt.Sprintf("https://api.twitch.tv/helix/predictions?broadcaster_id=%s&first=1", id),
+	}); err != nil {
+		return nil, fmt.Errorf("request predictions: %w", err)
+	}
+
+	if len(payload.Data) < 1 {
+		return nil, errors.New("no predictions found")
+	}
+
+	c.apiCache.Set(cacheKey, predictionCacheTimeout, payload.Data[0])
+
+	return payload.Data[0], nil
+}
+
+func (c *Client) executePredictionRequest(ctx context.Context, channel, method string, body *bytes.Buffer) (*PredictionInfo, error) {
+	var payload struct {
+		Data []*PredictionInfo `json:"data"`
+	}
+
+	if err := c.Request(ctx, ClientRequestOpts{
+		AuthType: AuthTypeBearerToken,
+		Body:     body,
+		Method:   method,
+		OKStatus: http.StatusOK,
+		Out:      &payload,
+		URL:      "https://api.twitch.tv/helix/predictions",
+	}); err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+
+	if len(payload.Data) < 1 {
+		return nil, errors.New("no prediction returned")
+	}
+
+	// Replace the cached prediction so subsequent calls do not see
+	// an outdated state
+	c.apiCache.Set([]string{"getLatestPrediction", channel}, predictionCacheTimeout, payload.Data[0])
+
+	return payload.Data[0], nil
+}
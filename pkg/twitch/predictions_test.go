@@ -0,0 +1,110 @@
+package twitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRoundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f testRoundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// newTestHelixClient returns a client with a validated token and a
+// known ID (123) for "testchannel" whose requests are answered by the
+// given handler instead of the Twitch API
+func newTestHelixClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	transport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = testRoundTripFunc(func(r *http.Request) (*http.Response, error) {
+		resp := httptest.NewRecorder()
+		handler(resp, r)
+		return resp.Result(), nil
+	})
+	t.Cleanup(func() { http.DefaultClient.Transport = transport })
+
+	c := New("id", "secret", "token", "")
+	c.tokenValidity = time.Now().Add(time.Hour)
+	c.tokenValidityChecked = time.Now()
+	c.apiCache.Set([]string{"idForUsername", "testchannel"}, time.Hour, "123")
+
+	return c
+}
+
+func TestPredictionRequests(t *testing.T) {
+	var (
+		reqMethod string
+		reqBody   map[string]any
+	)
+
+	c := newTestHelixClient(t, func(w http.ResponseWriter, r *http.Request) {
+		reqMethod, reqBody = r.Method, nil
+		if r.Body != nil && r.Method != http.MethodGet {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&reqBody))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"id": "pred", "status": PredictionStatusActive, "title": "Boss?"}},
+		}))
+	})
+
+	p, err := c.CreatePrediction(context.Background(), "testchannel", "Boss?", []string{"Yes", "No"}, 2*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "pred", p.ID)
+	assert.Equal(t, http.MethodPost, reqMethod)
+	assert.Equal(t, map[string]any{
+		"broadcaster_id":    "123",
+		"title":             "Boss?",
+		"outcomes":          []any{map[string]any{"title": "Yes"}, map[string]any{"title": "No"}},
+		"prediction_window": float64(120),
+	}, reqBody)
+
+	for name, tc := range map[string]struct {
+		status, winner string
+		expBody        map[string]any
+	}{
+		"lock": {PredictionStatusLocked, "o1", map[string]any{
+			"broadcaster_id": "123", "id": "pred", "status": PredictionStatusLocked,
+		}},
+		"cancel": {PredictionStatusCanceled, "", map[string]any{
+			"broadcaster_id": "123", "id": "pred", "status": PredictionStatusCanceled,
+		}},
+		"resolve": {PredictionStatusResolved, "o1", map[string]any{
+			"broadcaster_id": "123", "id": "pred", "status": PredictionStatusResolved, "winning_outcome_id": "o1",
+		}},
+	} {
+		_, err = c.EndPrediction(context.Background(), "testchannel", "pred", tc.status, tc.winner)
+		require.NoError(t, err, name)
+		assert.Equal(t, http.MethodPatch, reqMethod, name)
+		assert.Equal(t, tc.expBody, reqBody, name)
+	}
+
+	// Latest prediction is served from the cache updated by the last request
+	reqMethod = ""
+	p, err = c.GetLatestPrediction(context.Background(), "testchannel")
+	require.NoError(t, err)
+	assert.Equal(t, "pred", p.ID)
+	assert.Empty(t, reqMethod)
+}
+
+func TestPredictionRequestsEmptyResponse(t *testing.T) {
+	c := newTestHelixClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":[]}`))
+		assert.NoError(t, err)
+	})
+
+	_, err := c.GetLatestPrediction(context.Background(), "testchannel")
+	assert.Error(t, err)
+
+	_, err = c.EndPrediction(context.Background(), "testchannel", "pred", PredictionStatusCanceled, "")
+	assert.Error(t, err)
+}
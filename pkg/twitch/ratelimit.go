@@ -0,0 +1,386 @@
+package twitch
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Collection of priorities a request can be queued with when the
+// rate-limit bucket it belongs to is exhausted
+const (
+	// RequestPriorityDefault derives the priority from the request:
+	// moderation endpoints are queued with RequestPriorityHigh, all
+	// other requests with RequestPriorityNormal
+	RequestPriorityDefault RequestPriority = iota
+	RequestPriorityLow
+	RequestPriorityNormal
+	RequestPriorityHigh
+)
+
+const (
+	rateLimitFallbackWait = time.Second
+	rateLimitHost         = "api.twitch.tv"
+	rateLimitRetries      = 10
+)
+
+type (
+	// RateLimitBucketStatus describes the state of one rate-limit
+	// bucket (app-access-token or user-token) tracked by the clients
+	RateLimitBucketStatus struct {
+		Name      string    `json:"name"`
+		Limit     int64     `json:"limit"`
+		Remaining int64     `json:"remaining"`
+		Reset     time.Time `json:"reset"`
+		InFlight  int64     `json:"in_flight"`
+		Queued    int       `json:"queued"`
+	}
+
+	// RequestPriority defines in which order queued requests are
+	// executed once the rate-limit bucket allows more requests
+	RequestPriority uint8
+
+	// rateLimitBucket tracks the Ratelimit-* headers of the Helix API
+	// for one token and queues requests while the bucket is exhausted
+	rateLimitBucket struct {
+		name string
+
+		limit     int64
+		remaining int64 // -1 = unknown, requests are not held back
+		reset     time.Time
+		inFlight  int64
+
+		waiters []*rateLimitWaiter
+		timer   *time.Timer
+		evicted bool
+
+		lock sync.Mutex
+	}
+
+	rateLimitWaiter struct {
+		priority RequestPriority
+		ready    chan struct{}
+	}
+)
+
+var (
+	// errRateLimited signals the request needs to be repeated after
+	// the rate-limit bucket was reset
+	errRateLimited = errors.New("request was rate-limited")
+
+	// errRateLimitBucketEvicted signals the bucket was removed from
+	// the registry and a fresh one needs to be fetched
+	errRateLimitBucketEvicted = errors.New("rate-limit bucket was evicted")
+
+	// Clients are created per channel and request so the buckets
+	// need to be shared between all of them
+	rateLimitBuckets     = map[string]*rateLimitBucket{}
+	rateLimitBucketsLock sync.Mutex
+)
+
+// RateLimitQueueDepth returns the number of requests currently
+// waiting for any rate-limit bucket to allow their execution
+func RateLimitQueueDepth() (depth int) {
+	for _, b := range RateLimitStatus() {
+		depth += b.Queued
+	}
+
+	return depth
+}
+
+// RateLimitStatus returns a copy of the state of all known rate-limit
+// buckets sorted by their name
+func RateLimitStatus() []RateLimitBucketStatus {
+	rateLimitBucketsLock.Lock()
+	defer rateLimitBucketsLock.Unlock()
+
+	out := make([]RateLimitBucketStatus, 0, len(rateLimitBuckets))
+	for _, b := range rateLimitBuckets {
+		out = append(out, b.status())
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out
+}
+
+// acquireRateLimitBucket waits for the bucket with the given key to
+// allow another request and returns the bucket the slot was taken from
+func acquireRateLimitBucket(ctx context.Context, key string, priority RequestPriority) (*rateLimitBucket, error) {
+	for {
+		b := getRateLimitBucket(key)
+
+		err := b.acquire(ctx, priority)
+		if errors.Is(err, errRateLimitBucketEvicted) {
+			// Bucket was removed between fetching and acquiring it
+			continue
+		}
+
+		return b, err
+	}
+}
+
+// getRateLimitBucket returns the shared bucket for the given key and
+// creates it if required. On creation idle buckets of tokens no longer
+// in use are cleaned up.
+func getRateLimitBucket(key string) *rateLimitBucket {
+	rateLimitBucketsLock.Lock()
+	defer rateLimitBucketsLock.Unlock()
+
+	if b, ok := rateLimitBuckets[key]; ok {
+		return b
+	}
+
+	for k, b := range rateLimitBuckets {
+		if b.evictIfIdle(time.Now()) {
+			delete(rateLimitBuckets, k)
+		}
+	}
+
+	b := &rateLimitBucket{name: key, remaining: -1}
+	rateLimitBuckets[key] = b
+
+	return b
+}
+
+// rateLimitBucketKey returns the key of the bucket the request is
+// accounted to or an empty string if the request is not subject to
+// the Helix rate-limit
+func (c *Client) rateLimitBucketKey(opts ClientRequestOpts) string {
+	u, err := url.Parse(opts.URL)
+	if err != nil || u.Host != rateLimitHost {
+		return ""
+	}
+
+	switch opts.AuthType {
+	case AuthTypeAppAccessToken:
+		return "app/" + c.clientID
+
+	case AuthTypeBearerToken:
+		// The access-token changes on every refresh while the
+		// refresh-token stays the same for the user
+		token := c.refreshToken
+		if token == "" {
+			token = c.accessToken
+		}
+
+		hash := sha256.Sum256([]byte(c.clientID + ":" + token))
+		return fmt.Sprintf("user/%x", hash[:6])
+
+	default:
+		return ""
+	}
+}
+
+// requestPriority resolves RequestPriorityDefault for the given request
+func requestPriority(opts ClientRequestOpts) RequestPriority {
+	if opts.Priority != RequestPriorityDefault {
+		return opts.Priority
+	}
+
+	if u, err := url.Parse(opts.URL); err == nil && strings.HasPrefix(u.Path, "/helix/moderation/") {
+		return RequestPriorityHigh
+	}
+
+	return RequestPriorityNormal
+}
+
+// acquire blocks until the bucket allows another request or the
+// context is cancelled. Waiting requests are released by priority
+// and in order of their arrival within the same priority.
+func (r *rateLimitBucket) acquire(ctx context.Context, priority RequestPriority) error {
+	r.lock.Lock()
+
+	if r.evicted {
+		r.lock.Unlock()
+		return errRateLimitBucketEvicted
+	}
+
+	if len(r.waiters) == 0 && r.takeSlot(time.Now()) {
+		r.lock.Unlock()
+		return nil
+	}
+
+	w := &rateLimitWaiter{priority: priority, ready: make(chan struct{})}
+	r.enqueue(w)
+	r.scheduleDispatch()
+	r.lock.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+
+	case <-ctx.Done():
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		if !r.dequeue(w) {
+			// We got the slot while giving up, hand it to the next one
+			r.inFlight--
+			if r.remaining >= 0 {
+				r.remaining++
+			}
+			r.dispatch()
+		}
+
+		return fmt.Errorf("waiting for rate-limit: %w", ctx.Err())
+	}
+}
+
+// dispatch releases as many waiters as the bucket allows and schedules
+// the next dispatch for the remaining ones. Must be called with the
+// lock being held.
+func (r *rateLimitBucket) dispatch() {
+	for len(r.waiters) > 0 && r.takeSlot(time.Now()) {
+		w := r.waiters[0]
+		r.waiters = r.waiters[1:]
+		close(w.ready)
+	}
+
+	r.scheduleDispatch()
+}
+
+// dequeue removes the waiter from the queue and returns whether it
+// still was queued. Must be called with the lock being held.
+func (r *rateLimitBucket) dequeue(w *rateLimitWaiter) bool {
+	for i := range r.waiters {
+		if r.waiters[i] == w {
+			r.waiters = append(r.waiters[:i], r.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// enqueue inserts the waiter behind all waiters with the same or a
+// higher priority. Must be called with the lock being held.
+func (r *rateLimitBucket) enqueue(w *rateLimitWaiter) {
+	idx := sort.Search(len(r.waiters), func(i int) bool { return r.waiters[i].priority < w.priority })
+	r.waiters = append(r.waiters[:idx], append([]*rateLimitWaiter{w}, r.waiters[idx:]...)...)
+}
+
+// evictIfIdle marks the bucket as evicted if it has no requests and
+// its state is outdated. As acquire checks the mark under the same
+// lock, no request can be accounted to an evicted bucket.
+func (r *rateLimitBucket) evictIfIdle(now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.evicted = len(r.waiters) == 0 && r.inFlight == 0 && !now.Before(r.reset)
+	return r.evicted
+}
+
+// release returns the slot taken by acquire and updates the bucket
+// from the Ratelimit-* headers of the response (if any)
+func (r *rateLimitBucket) release(resp *http.Response) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.inFlight--
+
+	if resp != nil {
+		r.update(resp)
+	}
+
+	r.dispatch()
+}
+
+// scheduleDispatch starts a timer to dispatch waiters as soon as the
+// bucket is reset. Must be called with the lock being held.
+func (r *rateLimitBucket) scheduleDispatch() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+
+	if len(r.waiters) == 0 {
+		return
+	}
+
+	wait := time.Until(r.reset)
+	if wait <= 0 {
+		// Bucket is waiting for in-flight requests to finish, those
+		// will dispatch on release but better be safe than stuck
+		wait = rateLimitFallbackWait
+	}
+
+	r.timer = time.AfterFunc(wait, func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		r.dispatch()
+	})
+}
+
+func (r *rateLimitBucket) status() RateLimitBucketStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return RateLimitBucketStatus{
+		Name:      r.name,
+		Limit:     r.limit,
+		Remaining: r.remaining,
+		Reset:     r.reset,
+		InFlight:  r.inFlight,
+		Queued:    len(r.waiters),
+	}
+}
+
+// takeSlot checks whether another request is allowed and accounts it
+// to the bucket. Must be called with the lock being held.
+func (r *rateLimitBucket) takeSlot(now time.Time) bool {
+	if !r.reset.IsZero() && !now.Before(r.reset) {
+		// Bucket was refilled by Twitch, we will know the exact value
+		// after the next response. Until then requests still in flight
+		// might already count towards the new period.
+		r.remaining = -1
+		if r.limit > 0 {
+			r.remaining = max(r.limit-r.inFlight, 0)
+		}
+		r.reset = time.Time{}
+	}
+
+	if r.remaining == 0 {
+		return false
+	}
+
+	if r.remaining > 0 {
+		r.remaining--
+	}
+	r.inFlight++
+
+	return true
+}
+
+// update reads the Ratelimit-* headers from the response. Must be
+// called with the lock being held.
+func (r *rateLimitBucket) update(resp *http.Response) {
+	limit, errLimit := strconv.ParseInt(resp.Header.Get("Ratelimit-Limit"), 10, 64)
+	remaining, errRemaining := strconv.ParseInt(resp.Header.Get("Ratelimit-Remaining"), 10, 64)
+	reset, errReset := strconv.ParseInt(resp.Header.Get("Ratelimit-Reset"), 10, 64)
+
+	switch {
+	case errRemaining == nil && errReset == nil:
+		if errLimit == nil {
+			r.limit = limit
+		}
+		// Requests still in flight were issued before this response
+		// was created and are not yet contained in the header value
+		r.remaining = max(remaining-r.inFlight, 0)
+		r.reset = time.Unix(reset, 0)
+
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// We've been told to stop without being told for how long
+		r.remaining = 0
+		r.reset = time.Now().Add(rateLimitFallbackWait)
+	}
+}
@@ -0,0 +1,115 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitBucketAcquire(t *testing.T) {
+	b := &rateLimitBucket{name: "test", remaining: -1}
+
+	// Unknown state does not hold back requests
+	require.NoError(t, b.acquire(context.Background(), RequestPriorityNormal))
+	assert.Equal(t, int64(1), b.inFlight)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("Ratelimit-Limit", "800")
+	resp.Header.Set("Ratelimit-Remaining", "0")
+	resp.Header.Set("Ratelimit-Reset", strconv.FormatInt(time.Now().Add(2*time.Second).Unix(), 10))
+	b.release(resp)
+
+	assert.Equal(t, int64(0), b.inFlight)
+	assert.Equal(t, int64(800), b.limit)
+	assert.Equal(t, int64(0), b.remaining)
+
+	// Exhausted bucket lets cancelled requests fail
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Error(t, b.acquire(ctx, RequestPriorityHigh))
+	assert.Empty(t, b.waiters)
+
+	// Exhausted bucket holds back requests until reset
+	start := time.Now()
+	require.NoError(t, b.acquire(context.Background(), RequestPriorityNormal))
+	assert.Greater(t, time.Since(start), 500*time.Millisecond)
+
+	b.release(nil)
+	assert.Equal(t, 0, b.status().Queued)
+}
+
+func TestRateLimitBucketEviction(t *testing.T) {
+	b := getRateLimitBucket("test/evict")
+
+	// A bucket fetched before being evicted must not be used
+	require.True(t, b.evictIfIdle(time.Now()))
+	assert.ErrorIs(t, b.acquire(context.Background(), RequestPriorityNormal), errRateLimitBucketEvicted)
+
+	rateLimitBucketsLock.Lock()
+	delete(rateLimitBuckets, "test/evict")
+	rateLimitBucketsLock.Unlock()
+
+	nb, err := acquireRateLimitBucket(context.Background(), "test/evict", RequestPriorityNormal)
+	require.NoError(t, err)
+	assert.NotSame(t, b, nb)
+
+	// A bucket with requests in flight is not evicted
+	assert.False(t, nb.evictIfIdle(time.Now()))
+	nb.release(nil)
+}
+
+func TestRateLimitBucketRefill(t *testing.T) {
+	now := time.Now()
+	b := &rateLimitBucket{name: "test", limit: 3, remaining: 0, reset: now.Add(-time.Second), inFlight: 1}
+
+	// Refilled bucket allows no more requests than the limit
+	assert.True(t, b.takeSlot(now))
+	assert.True(t, b.takeSlot(now))
+	assert.False(t, b.takeSlot(now))
+	assert.Equal(t, int64(3), b.inFlight)
+
+	// Bucket without known limit does not hold back requests
+	b = &rateLimitBucket{name: "test", remaining: 0, reset: now.Add(-time.Second)}
+	for range 5 {
+		assert.True(t, b.takeSlot(now))
+	}
+}
+
+func TestRateLimitBucketEnqueue(t *testing.T) {
+	b := &rateLimitBucket{name: "test"}
+
+	var (
+		low     = &rateLimitWaiter{priority: RequestPriorityLow}
+		normal1 = &rateLimitWaiter{priority: RequestPriorityNormal}
+		normal2 = &rateLimitWaiter{priority: RequestPriorityNormal}
+		high    = &rateLimitWaiter{priority: RequestPriorityHigh}
+	)
+
+	for _, w := range []*rateLimitWaiter{normal1, low, high, normal2} {
+		b.enqueue(w)
+	}
+
+	assert.Equal(t, []*rateLimitWaiter{high, normal1, normal2, low}, b.waiters)
+
+	assert.True(t, b.dequeue(normal1))
+	assert.False(t, b.dequeue(normal1))
+	assert.Equal(t, []*rateLimitWaiter{high, normal2, low}, b.waiters)
+}
+
+func TestRequestPriority(t *testing.T) {
+	for _, tc := range []struct {
+		opts ClientRequestOpts
+		exp  RequestPriority
+	}{
+		{ClientRequestOpts{URL: "https://api.twitch.tv/helix/moderation/bans"}, RequestPriorityHigh},
+		{ClientRequestOpts{URL: "https://api.twitch.tv/helix/users"}, RequestPriorityNormal},
+		{ClientRequestOpts{URL: "https://api.twitch.tv/helix/users", Priority: RequestPriorityLow}, RequestPriorityLow},
+	} {
+		assert.Equal(t, tc.exp, requestPriority(tc.opts), tc.opts.URL)
+	}
+}
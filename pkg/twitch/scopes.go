@@ -3,6 +3,7 @@ package twitch
 // Collection of known API scopes
 const (
 	// API Scopes
+	ScopeBitsRead                     = "bits:read"
 	ScopeChannelBot                   = "channel:bot"
 	ScopeChannelEditCommercial        = "channel:edit:commercial"
 	ScopeChannelManageAds             = "channel:manage:ads"
@@ -17,18 +18,31 @@ const (
 	ScopeChannelReadAds               = "channel:read:ads"
 	ScopeChannelReadHypetrain         = "channel:read:hype_train"
 	ScopeChannelReadPolls             = "channel:read:polls"
+	ScopeChannelReadPredictions       = "channel:read:predictions"
 	ScopeChannelReadRedemptions       = "channel:read:redemptions"
 	ScopeChannelReadSubscriptions     = "channel:read:subscriptions"
 	ScopeClipsEdit                    = "clips:edit"
 	ScopeModeratorManageAnnoucements  = "moderator:manage:announcements"
+	ScopeModeratorManageAutomod       = "moderator:manage:automod"
 	ScopeModeratorManageBannedUsers   = "moderator:manage:banned_users"
+	ScopeModeratorManageBlockedTerms  = "moderator:manage:blocked_terms"
 	ScopeModeratorManageChatMessages  = "moderator:manage:chat_messages"
 	ScopeModeratorManageChatSettings  = "moderator:manage:chat_settings"
 	ScopeModeratorManageShieldMode    = "moderator:manage:shield_mode"
 	ScopeModeratorManageShoutouts     = "moderator:manage:shoutouts"
+	ScopeModeratorManageUnbanRequests = "moderator:manage:unban_requests"
+	ScopeModeratorManageWarnings      = "moderator:manage:warnings"
+	ScopeModeratorReadBannedUsers     = "moderator:read:banned_users"
+	ScopeModeratorReadBlockedTerms    = "moderator:read:blocked_terms"
+	ScopeModeratorReadChatMessages    = "moderator:read:chat_messages"
+	ScopeModeratorReadChatSettings    = "moderator:read:chat_settings"
 	ScopeModeratorReadFollowers       = "moderator:read:followers"
+	ScopeModeratorReadModerators      = "moderator:read:moderators"
 	ScopeModeratorReadShoutouts       = "moderator:read:shoutouts"
 	ScopeModeratorReadSuspiciousUsers = "moderator:read:suspicious_users"
+	ScopeModeratorReadUnbanRequests   = "moderator:read:unban_requests"
+	ScopeModeratorReadVIPs            = "moderator:read:vips"
+	ScopeModeratorReadWarnings        = "moderator:read:warnings"
 	ScopeUserBot                      = "user:bot"
 	ScopeUserManageChatColor          = "user:manage:chat_color"
 	ScopeUserManageWhispers           = "user:manage:whispers"
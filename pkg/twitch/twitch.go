@@ -2,6 +2,7 @@
 package twitch
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/json"
@@ -92,6 +93,7 @@ type (
 		NoValidateToken bool
 		OKStatus        int
 		Out             any
+		Priority        RequestPriority
 		URL             string
 		ValidateFunc    func(ClientRequestOpts, *http.Response) error
 	}
@@ -104,7 +106,8 @@ type (
 // When the status is http.StatusTooManyRequests the function will
 // return an error terminating any retries as retrying would not make
 // sense (the error returned from Request will still be an HTTPError
-// with status 429).
+// with status 429). Requests to the Helix API only end up here after
+// waiting for the rate-limit to reset did not help multiple times.
 //
 // When wrapping this function the body should not have been read
 // before in order to have the response body available in the returned
@@ -260,9 +263,9 @@ func (c *Client) RefreshToken(ctx context.Context) error {
 
 // Request executes the request towards the Twitch API defined by the
 // ClientRequestOpts and takes care of token management and response
-// checking
-//
-//nolint:gocyclo,gocognit // Not gonna split to keep as a logical unit
+// checking. Requests to the Helix API are queued by their priority
+// while the rate-limit of the used token is exhausted and are
+// transparently repeated when being rate-limited.
 func (c *Client) Request(ctx context.Context, opts ClientRequestOpts) error {
 	logrus.WithFields(logrus.Fields{
 		"method": opts.Method,
@@ -279,75 +282,34 @@ func (c *Client) Request(ctx context.Context, opts ClientRequestOpts) error {
 		opts.ValidateFunc = ValidateStatus
 	}
 
-	//nolint:wrapcheck // The backoff library returns our own errors
-	return backoff.NewBackoff().WithMaxIterations(retries).Retry(func() error {
-		reqCtx, cancel := context.WithTimeout(ctx, twitchRequestTimeout)
-		defer cancel()
-
-		req, err := http.NewRequestWithContext(reqCtx, opts.Method, opts.URL, opts.Body)
-		if err != nil {
-			return fmt.Errorf("assemble request: %w", err)
+	var body []byte
+	if opts.Body != nil {
+		// A rate-limited request was not processed by Twitch and is
+		// sent again so the body needs to be kept
+		var err error
+		if body, err = io.ReadAll(opts.Body); err != nil {
+			return fmt.Errorf("reading request body: %w", err)
 		}
-		req.Header.Set("Content-Type", "application/json")
-
-		switch opts.AuthType {
-		case AuthTypeUnauthorized:
-			// Nothing to do
-
-		case AuthTypeAppAccessToken:
-			accessToken, err := c.GetTwitchAppAccessToken(ctx)
-			if err != nil {
-				return fmt.Errorf("getting app-access-token: %w", err)
-			}
-
-			req.Header.Set("Authorization", "Bearer "+accessToken)
-			req.Header.Set("Client-Id", c.clientID)
-
-		case AuthTypeBearerToken:
-			accessToken := c.accessToken
-			if !opts.NoValidateToken {
-				accessToken, err = c.GetToken(reqCtx)
-				if err != nil {
-					return fmt.Errorf("getting bearer access token: %w", err)
-				}
-			}
-
-			req.Header.Set("Authorization", "Bearer "+accessToken)
-			req.Header.Set("Client-Id", c.clientID)
+	}
 
-		default:
-			return errors.New("invalid auth type specified")
-		}
+	var (
+		bucketKey = c.rateLimitBucketKey(opts)
+		priority  = requestPriority(opts)
+	)
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("execute request: %w", err)
-		}
-		defer func() {
-			if err := resp.Body.Close(); err != nil {
-				logrus.WithError(err).Error("closing response body (leaked fd)")
+	//nolint:wrapcheck // The backoff library returns our own errors
+	return backoff.NewBackoff().WithMaxIterations(retries).Retry(func() error {
+		for attempt := 1; ; attempt++ {
+			err := c.executeRequest(ctx, opts, body, bucketKey, priority, attempt < rateLimitRetries)
+			if !errors.Is(err, errRateLimited) {
+				return err
 			}
-		}()
 
-		if opts.AuthType == AuthTypeAppAccessToken && resp.StatusCode == http.StatusUnauthorized {
-			// Seems our token was somehow revoked, clear the token and retry which will get a new token
-			c.appAccessToken = ""
-			return errors.New("app-access-token is invalid")
+			logrus.WithFields(logrus.Fields{
+				"attempt": attempt,
+				"url":     c.replaceSecrets(opts.URL),
+			}).Debug("Twitch API request was rate-limited, waiting for reset")
 		}
-
-		if err = opts.ValidateFunc(opts, resp); err != nil {
-			return err
-		}
-
-		if opts.Out == nil {
-			return nil
-		}
-
-		if err = json.NewDecoder(resp.Body).Decode(opts.Out); err != nil {
-			return fmt.Errorf("parsing user info: %w", err)
-		}
-
-		return nil
 	})
 }
 
@@ -411,6 +373,105 @@ func (c *Client) ValidateToken(ctx context.Context, force bool) error {
 	return nil
 }
 
+// executeRequest executes one attempt of the Request. When the request
+// is rate-limited and waitOnRateLimit is set errRateLimited is
+// returned instead of passing the response to the ValidateFunc.
+//
+//nolint:gocyclo,gocognit // Not gonna split to keep as a logical unit
+func (c *Client) executeRequest(ctx context.Context, opts ClientRequestOpts, body []byte, bucketKey string, priority RequestPriority, waitOnRateLimit bool) (err error) {
+	var bucket *rateLimitBucket
+	if bucketKey != "" {
+		// Waiting for the bucket must not be limited by the request
+		// timeout so this is done before creating the request context
+		if bucket, err = acquireRateLimitBucket(ctx, bucketKey, priority); err != nil {
+			return backoff.NewErrCannotRetry(err) //nolint:wrapcheck // We'll get our internal error
+		}
+	}
+
+	var resp *http.Response
+	defer func() {
+		if bucket != nil {
+			bucket.release(resp)
+		}
+	}()
+
+	reqCtx, cancel := context.WithTimeout(ctx, twitchRequestTimeout)
+	defer cancel()
+
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, opts.Method, opts.URL, reqBody)
+	if err != nil {
+		return fmt.Errorf("assemble request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	switch opts.AuthType {
+	case AuthTypeUnauthorized:
+		// Nothing to do
+
+	case AuthTypeAppAccessToken:
+		accessToken, err := c.GetTwitchAppAccessToken(ctx)
+		if err != nil {
+			return fmt.Errorf("getting app-access-token: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("Client-Id", c.clientID)
+
+	case AuthTypeBearerToken:
+		accessToken := c.accessToken
+		if !opts.NoValidateToken {
+			accessToken, err = c.GetToken(reqCtx)
+			if err != nil {
+				return fmt.Errorf("getting bearer access token: %w", err)
+			}
+		}
+
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("Client-Id", c.clientID)
+
+	default:
+		return errors.New("invalid auth type specified")
+	}
+
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		return fmt.Errorf("execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logrus.WithError(err).Error("closing response body (leaked fd)")
+		}
+	}()
+
+	if bucket != nil && waitOnRateLimit && resp.StatusCode == http.StatusTooManyRequests {
+		return errRateLimited
+	}
+
+	if opts.AuthType == AuthTypeAppAccessToken && resp.StatusCode == http.StatusUnauthorized {
+		// Seems our token was somehow revoked, clear the token and retry which will get a new token
+		c.appAccessToken = ""
+		return errors.New("app-access-token is invalid")
+	}
+
+	if err = opts.ValidateFunc(opts, resp); err != nil {
+		return err
+	}
+
+	if opts.Out == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(opts.Out); err != nil {
+		return fmt.Errorf("parsing user info: %w", err)
+	}
+
+	return nil
+}
+
 func (*Client) hashSecret(secret string) string {
 	return fmt.Sprintf("[sha256:%x]", sha256.Sum256([]byte(secret)))
 }
@@ -2,6 +2,7 @@ package plugins
 
 // Enum of available field types
 const (
+	ActionDocumentationFieldTypeActionList  ActionDocumentationFieldType = "actionlist"
 	ActionDocumentationFieldTypeBool        ActionDocumentationFieldType = "bool"
 	ActionDocumentationFieldTypeDuration    ActionDocumentationFieldType = "duration"
 	ActionDocumentationFieldTypeInt64       ActionDocumentationFieldType = "int64"
@@ -1,6 +1,8 @@
 package plugins
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -39,3 +41,40 @@ func DeriveUser(m *irc.Message, evtData *fieldcollection.FieldCollection) string
 
 	return ""
 }
+
+// ParseRuleActions reads a list of RuleActions from the given
+// attribute key. If the key is not set no actions and no error are
+// returned.
+func ParseRuleActions(attrs *fieldcollection.FieldCollection, key string) ([]*RuleAction, error) {
+	raw, err := attrs.Get(key)
+	if err != nil {
+		if errors.Is(err, fieldcollection.ErrValueNotSet) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting field %s: %w", key, err)
+	}
+
+	// The attributes are decoded from YAML or JSON into generic types,
+	// so we re-encode them to parse them into the proper structure
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encoding field %s: %w", key, err)
+	}
+
+	var actions []*RuleAction
+	if err = json.Unmarshal(data, &actions); err != nil {
+		return nil, fmt.Errorf("decoding field %s into actions: %w", key, err)
+	}
+
+	for idx, a := range actions {
+		if a == nil || a.Type == "" {
+			return nil, fmt.Errorf("action %d in field %s has no type", idx, key)
+		}
+
+		if a.Attributes == nil {
+			a.Attributes = fieldcollection.NewFieldCollection()
+		}
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,39 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRuleActions(t *testing.T) {
+	attrs := fieldcollection.FieldCollectionFromData(map[string]any{
+		"then": []any{
+			map[string]any{
+				"type":       "respond",
+				"attributes": map[string]any{"message": "Hi {{ .username }}"},
+			},
+			map[string]any{"type": "stopexec"},
+		},
+		"broken": []any{
+			map[string]any{"attributes": map[string]any{}},
+		},
+	})
+
+	actions, err := ParseRuleActions(attrs, "then")
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "respond", actions[0].Type)
+	assert.Equal(t, "Hi {{ .username }}", actions[0].Attributes.MustString("message", nil))
+	assert.Equal(t, "stopexec", actions[1].Type)
+	assert.NotNil(t, actions[1].Attributes)
+
+	actions, err = ParseRuleActions(attrs, "else")
+	require.NoError(t, err)
+	assert.Nil(t, actions)
+
+	_, err = ParseRuleActions(attrs, "broken")
+	assert.Error(t, err)
+}
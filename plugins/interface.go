@@ -14,6 +14,20 @@ import (
 )
 
 type (
+	// ActionExecutionFunc is passed from the bot to the plugins
+	// RegisterFunc to execute a (nested) list of RuleActions in the
+	// context of the current rule execution
+	ActionExecutionFunc func(c *irc.Client, m *irc.Message, r *Rule, actions []*RuleAction, evtData *fieldcollection.FieldCollection) (preventCooldown bool, err error)
+
+	// ActionSetGetterFunc retrieves the actions of the named action set
+	// defined in the bot configuration
+	ActionSetGetterFunc func(name string) ([]*RuleAction, error)
+
+	// ActionValidationFunc is passed from the bot to the plugins
+	// RegisterFunc to validate a (nested) list of RuleActions using
+	// the Validate functions of their actors
+	ActionValidationFunc func(actions []*RuleAction) error
+
 	// Actor defines an interface to implement in the plugin for actors
 	Actor interface {
 		// Execute will be called after the config was read into the Actor
@@ -110,10 +124,14 @@ type (
 	RegistrationArguments struct {
 		// CreateEvent allows to create an event handed out to all modules to handle
 		CreateEvent EventHandlerFunc
+		// ExecuteActions executes a list of RuleActions in the context of the current rule
+		ExecuteActions ActionExecutionFunc
 		// FormatMessage is a method to convert templates into strings using internally known variables / configs
 		FormatMessage MsgFormatter
 		// FrontendNotify is a way to send a notification to the frontend
 		FrontendNotify func(string)
+		// GetActionSet retrieves the actions of a named action set from the current config
+		GetActionSet ActionSetGetterFunc
 		// GetBaseURL returns the configured BaseURL for the bot
 		GetBaseURL func() string
 		// GetDatabaseConnector returns an active database.Connector to access the backend storage database
@@ -152,6 +170,8 @@ type (
 		RegisterTemplateFunction TemplateFuncRegister
 		// SendMessage can be used to send a message not triggered by an event
 		SendMessage SendMessageFunc
+		// ValidateActions validates a list of RuleActions using the Validate functions of their actors
+		ValidateActions ActionValidationFunc
 		// ValidateToken offers a way to validate a token and determine whether it has permissions on a given module
 		ValidateToken ValidateTokenFunc
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/Luzifer/go_helpers/fieldcollection"
 	"github.com/mitchellh/hashstructure/v2"
+	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/irc.v4"
 	"gopkg.in/yaml.v3"
@@ -27,6 +29,10 @@ const (
 	contentTypeYAML = "yaml"
 
 	remoteRuleFetchTimeout = 5 * time.Second
+
+	// EventTypeCron is the synthetic event passed to rules triggered
+	// through their match_cron schedule
+	EventTypeCron = "cron"
 )
 
 type (
@@ -36,6 +42,16 @@ type (
 		Description   string  `json:"description,omitempty" yaml:"description,omitempty"`
 		SubscribeFrom *string `json:"subscribe_from,omitempty" yaml:"subscribe_from,omitempty"`
 
+		// Priority defines the order in which matching rules of the
+		// same group are considered, rules with higher priority first.
+		// Rules without group are executed in parallel, so it has no
+		// effect on them.
+		Priority int64 `json:"priority,omitempty" yaml:"priority,omitempty"`
+		// Group makes rules exclusive to each other: of all matching
+		// rules within the same group only the one with the highest
+		// priority not being on cooldown is executed
+		Group string `json:"group,omitempty" yaml:"group,omitempty"`
+
 		Actions []*RuleAction `json:"actions,omitempty" yaml:"actions,omitempty"`
 
 		Cooldown        *time.Duration `json:"cooldown,omitempty" yaml:"cooldown,omitempty"`
@@ -43,13 +59,26 @@ type (
 		UserCooldown    *time.Duration `json:"user_cooldown,omitempty" yaml:"user_cooldown,omitempty"`
 		SkipCooldownFor []string       `json:"skip_cooldown_for,omitempty" yaml:"skip_cooldown_for,omitempty"`
 
+		UsageLimit        *RuleUsageLimit `json:"usage_limit,omitempty" yaml:"usage_limit,omitempty"`
+		ChannelUsageLimit *RuleUsageLimit `json:"channel_usage_limit,omitempty" yaml:"channel_usage_limit,omitempty"`
+		UserUsageLimit    *RuleUsageLimit `json:"user_usage_limit,omitempty" yaml:"user_usage_limit,omitempty"`
+
 		MatchChannels []string `json:"match_channels,omitempty" yaml:"match_channels,omitempty"`
+		MatchCron     *string  `json:"match_cron,omitempty" yaml:"match_cron,omitempty"`
 		MatchEvent    *string  `json:"match_event,omitempty" yaml:"match_event,omitempty"`
 		MatchMessage  *string  `json:"match_message,omitempty" yaml:"match_message,omitempty"`
 		MatchUsers    []string `json:"match_users,omitempty" yaml:"match_users,omitempty" `
 
+		// MatchFields checks fields of the event data, all of them
+		// need to match for the rule to be executed
+		MatchFields []RuleFieldMatcher `json:"match_fields,omitempty" yaml:"match_fields,omitempty"`
+
 		DisableOnMatchMessages []string `json:"disable_on_match_messages,omitempty" yaml:"disable_on_match_messages,omitempty"`
 
+		// ActiveWindow restricts the rule to certain weekdays, times
+		// of day and / or dates
+		ActiveWindow *RuleActiveWindow `json:"active_window,omitempty" yaml:"active_window,omitempty"`
+
 		Disable           *bool    `json:"disable,omitempty" yaml:"disable,omitempty"`
 		DisableOnOffline  *bool    `json:"disable_on_offline,omitempty" yaml:"disable_on_offline,omitempty"`
 		DisableOnPermit   *bool    `json:"disable_on_permit,omitempty" yaml:"disable_on_permit,omitempty"`
@@ -62,24 +91,72 @@ type (
 		//revive:disable-next-line:confusing-naming // only used internally as parsed regexp
 		disableOnMatchMessages []*regexp.Regexp
 
-		msgFormatter MsgFormatter
-		timerStore   TimerStore
-		twitchClient *twitch.Client
+		channelTimezones ChannelTimezones
+		msgFormatter     MsgFormatter
+		timerStore       TimerStore
+		twitchClient     *twitch.Client
 	}
 
 	// RuleAction represents an action to be executed when running a Rule
 	RuleAction struct {
 		Type       string                           `json:"type" yaml:"type,omitempty"`
 		Attributes *fieldcollection.FieldCollection `json:"attributes" yaml:"attributes,omitempty"`
+
+		// Retry defines how often the action is retried when failing
+		Retry *RuleActionRetry `json:"retry,omitempty" yaml:"retry,omitempty"`
+		// OnError contains actions to execute when the action failed
+		// after all retries. They get the error message passed in the
+		// `error` field of the event data. When they succeed the rule
+		// execution is stopped gracefully: the remaining actions of the
+		// rule are skipped and no cooldown is set.
+		OnError []*RuleAction `json:"on_error,omitempty" yaml:"on_error,omitempty"`
+	}
+
+	// RuleActionRetry defines how often a failing action is retried
+	// and how long to wait before the first retry. The delay grows
+	// exponentially for every further retry.
+	RuleActionRetry struct {
+		Count int64         `json:"count" yaml:"count"`
+		Delay time.Duration `json:"delay,omitempty" yaml:"delay,omitempty"`
+	}
+
+	// RuleMatcherVerdict contains the result of a single matcher of
+	// the Rule when explaining the match result
+	RuleMatcherVerdict struct {
+		Matcher string `json:"matcher"`
+		Passed  bool   `json:"passed"`
+		Reason  string `json:"reason,omitempty"`
+	}
+
+	// RuleUsageLimit defines how many executions of a Rule are allowed
+	// within a sliding window of time. Each execution uses up one of
+	// the Count tokens which is refilled after Window has passed.
+	RuleUsageLimit struct {
+		Count  int64         `json:"count" yaml:"count"`
+		Window time.Duration `json:"window" yaml:"window"`
+	}
+
+	ruleMatcher struct {
+		name string
+		fn   func(*logrus.Entry, *irc.Message, *string, twitch.BadgeCollection, *fieldcollection.FieldCollection) bool
+	}
+
+	ruleMatcherReasonHook struct {
+		reasons []string
 	}
 )
 
+// CronParser parses cron specs with optional seconds and descriptors
+// (i.e. "@hourly") as used for rule and auto-message schedules
+var CronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // ErrStopRuleExecution is a way for actions to terminate execution
 // of the current rule gracefully. No actions after this has been
 // returned will be executed and no error state will be set
 var ErrStopRuleExecution = errors.New("stop rule execution now")
 
-// CanExecuteCooldowns re-checks only the cooldown-related matchers for a rule.
+// CanExecuteCooldowns re-checks only the cooldown-related matchers
+// (including the usage limits) for a rule.
 func (r *Rule) CanExecuteCooldowns(m *irc.Message, timerStore TimerStore, eventData *fieldcollection.FieldCollection) bool {
 	r.timerStore = timerStore
 
@@ -95,6 +172,9 @@ func (r *Rule) CanExecuteCooldowns(m *irc.Message, timerStore TimerStore, eventD
 		r.allowExecuteRuleCooldown,
 		r.allowExecuteChannelCooldown,
 		r.allowExecuteUserCooldown,
+		r.allowExecuteRuleUsageLimit,
+		r.allowExecuteChannelUsageLimit,
+		r.allowExecuteUserUsageLimit,
 	} {
 		if !matcher(logger, m, nil, badges, eventData) {
 			return false
@@ -104,21 +184,81 @@ func (r *Rule) CanExecuteCooldowns(m *irc.Message, timerStore TimerStore, eventD
 	return true
 }
 
+// CompileMatchers pre-compiles the regular expressions of the field
+// matchers and loads the timezone of the active window. As this
+// modifies the rule it must be done before the rule is used for
+// matching.
+func (r *Rule) CompileMatchers() error {
+	for i := range r.MatchFields {
+		if err := r.MatchFields[i].Compile(); err != nil {
+			return fmt.Errorf("compiling match_fields entry %d: %w", i, err)
+		}
+	}
+
+	if r.ActiveWindow != nil {
+		if err := r.ActiveWindow.Compile(); err != nil {
+			return fmt.Errorf("compiling active_window: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // CooldownLockKey derives the lock key to use for executing the rule.
-// The key is scoped to the narrowest cooldown dimension configured on the rule.
+// The key is scoped to the narrowest cooldown / usage limit dimension
+// configured on the rule.
 func (r *Rule) CooldownLockKey(m *irc.Message, eventData *fieldcollection.FieldCollection) string {
 	key := path.Join("rule-execution", r.MatcherID())
 
 	switch {
-	case r.ChannelCooldown != nil && DeriveChannel(m, eventData) != "":
+	case (r.ChannelCooldown != nil || r.ChannelUsageLimit != nil) && DeriveChannel(m, eventData) != "":
 		return path.Join(key, "channel", DeriveChannel(m, eventData))
-	case r.UserCooldown != nil && DeriveUser(m, eventData) != "":
+	case (r.UserCooldown != nil || r.UserUsageLimit != nil) && DeriveUser(m, eventData) != "":
 		return path.Join(key, "user", DeriveUser(m, eventData))
 	default:
 		return key
 	}
 }
 
+// Explain evaluates all matchers of the Rule for the given parameters
+// and reports the verdict of each of them. In contrast to Matches it
+// does not stop on the first non-matching matcher. No actions are
+// executed and no cooldowns are set.
+func (r *Rule) Explain(m *irc.Message, event *string, timerStore TimerStore, msgFormatter MsgFormatter, twitchClient *twitch.Client, channelTimezones ChannelTimezones, eventData *fieldcollection.FieldCollection) (matches bool, verdicts []RuleMatcherVerdict) {
+	r.channelTimezones = channelTimezones
+	r.msgFormatter = msgFormatter
+	r.timerStore = timerStore
+	r.twitchClient = twitchClient
+
+	var (
+		badges     = twitch.ParseBadgeLevels(m)
+		reasonHook = new(ruleMatcherReasonHook)
+		logger     = logrus.New()
+	)
+
+	// Matchers report their reasons through trace logs, we collect
+	// them through the hook instead of writing them out
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(reasonHook)
+
+	matches = true
+	for _, matcher := range r.matchers() {
+		reasonHook.reasons = nil
+
+		passed := matcher.fn(logrus.NewEntry(logger), m, event, badges, eventData)
+		matches = matches && passed
+
+		verdicts = append(verdicts, RuleMatcherVerdict{
+			Matcher: matcher.name,
+			Passed:  passed,
+			Reason:  strings.Join(reasonHook.reasons, "; "),
+		})
+	}
+
+	return matches, verdicts
+}
+
 // GetMatchMessage returns the cached Regexp if available or compiles
 // the given match string into a Regexp
 func (r *Rule) GetMatchMessage() *regexp.Regexp {
@@ -145,7 +285,8 @@ func (r Rule) MatcherID() string {
 }
 
 // Matches checks whether the Rule should be executed for the given parameters
-func (r *Rule) Matches(m *irc.Message, event *string, timerStore TimerStore, msgFormatter MsgFormatter, twitchClient *twitch.Client, eventData *fieldcollection.FieldCollection) bool {
+func (r *Rule) Matches(m *irc.Message, event *string, timerStore TimerStore, msgFormatter MsgFormatter, twitchClient *twitch.Client, channelTimezones ChannelTimezones, eventData *fieldcollection.FieldCollection) bool {
+	r.channelTimezones = channelTimezones
 	r.msgFormatter = msgFormatter
 	r.timerStore = timerStore
 	r.twitchClient = twitchClient
@@ -158,23 +299,8 @@ func (r *Rule) Matches(m *irc.Message, event *string, timerStore TimerStore, msg
 		})
 	)
 
-	for _, matcher := range []func(*logrus.Entry, *irc.Message, *string, twitch.BadgeCollection, *fieldcollection.FieldCollection) bool{
-		r.allowExecuteDisable,
-		r.allowExecuteChannelWhitelist,
-		r.allowExecuteUserWhitelist,
-		r.allowExecuteEventMatch,
-		r.allowExecuteMessageMatcherWhitelist,
-		r.allowExecuteMessageMatcherBlacklist,
-		r.allowExecuteBadgeBlacklist,
-		r.allowExecuteBadgeWhitelist,
-		r.allowExecuteDisableOnPermit,
-		r.allowExecuteRuleCooldown,
-		r.allowExecuteChannelCooldown,
-		r.allowExecuteUserCooldown,
-		r.allowExecuteDisableOnTemplate,
-		r.allowExecuteDisableOnOffline,
-	} {
-		if !matcher(logger, m, event, badges, eventData) {
+	for _, matcher := range r.matchers() {
+		if !matcher.fn(logger, m, event, badges, eventData) {
 			return false
 		}
 	}
@@ -184,7 +310,7 @@ func (r *Rule) Matches(m *irc.Message, event *string, timerStore TimerStore, msg
 }
 
 // SetCooldown uses the given TimerStore to set the cooldowns for the
-// Rule after execution
+// Rule after execution and to use up one token of each usage limit
 func (r *Rule) SetCooldown(timerStore TimerStore, m *irc.Message, evtData *fieldcollection.FieldCollection) {
 	var err error
 
@@ -205,6 +331,24 @@ func (r *Rule) SetCooldown(timerStore TimerStore, m *irc.Message, evtData *field
 			logrus.WithError(err).Error("setting user rule cooldown")
 		}
 	}
+
+	if r.UsageLimit != nil {
+		if err = r.consumeUsageLimit(timerStore, r.UsageLimit, "rule", ""); err != nil {
+			logrus.WithError(err).Error("consuming rule usage limit")
+		}
+	}
+
+	if r.ChannelUsageLimit != nil && DeriveChannel(m, evtData) != "" {
+		if err = r.consumeUsageLimit(timerStore, r.ChannelUsageLimit, "channel", DeriveChannel(m, evtData)); err != nil {
+			logrus.WithError(err).Error("consuming channel usage limit")
+		}
+	}
+
+	if r.UserUsageLimit != nil && DeriveUser(m, evtData) != "" {
+		if err = r.consumeUsageLimit(timerStore, r.UserUsageLimit, "user", DeriveUser(m, evtData)); err != nil {
+			logrus.WithError(err).Error("consuming user usage limit")
+		}
+	}
 }
 
 // UpdateFromSubscription fetches the remote Rule source if one is
@@ -288,9 +432,69 @@ func (r Rule) Validate(tplValidate TemplateValidatorFunc) error {
 		}
 	}
 
+	if r.MatchCron != nil {
+		if _, err := CronParser.Parse(*r.MatchCron); err != nil {
+			return fmt.Errorf("parsing match_cron schedule: %w", err)
+		}
+
+		if len(r.MatchChannels) == 0 {
+			return errors.New("match_cron requires match_channels to be set")
+		}
+
+		if r.MatchEvent != nil {
+			return errors.New("match_cron and match_event cannot be combined")
+		}
+	}
+
+	for i, fm := range r.MatchFields {
+		if err := fm.Validate(); err != nil {
+			return fmt.Errorf("validating match_fields entry %d: %w", i, err)
+		}
+	}
+
+	if r.ActiveWindow != nil {
+		if err := r.ActiveWindow.Validate(); err != nil {
+			return fmt.Errorf("validating active_window: %w", err)
+		}
+	}
+
+	for name, limit := range map[string]*RuleUsageLimit{
+		"usage_limit":         r.UsageLimit,
+		"channel_usage_limit": r.ChannelUsageLimit,
+		"user_usage_limit":    r.UserUsageLimit,
+	} {
+		if limit == nil {
+			continue
+		}
+
+		if limit.Count < 1 || limit.Window <= 0 {
+			return fmt.Errorf("%s needs a positive count and window", name)
+		}
+	}
+
 	return nil
 }
 
+func (r *Rule) allowExecuteActiveWindow(logger *logrus.Entry, m *irc.Message, _ *string, _ twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
+	if r.ActiveWindow == nil {
+		// No match criteria set, does not speak against matching
+		return true
+	}
+
+	active, reason, err := r.ActiveWindow.IsActiveAt(time.Now(), r.channelTimezones.Get(DeriveChannel(m, evtData)))
+	if err != nil {
+		logger.WithError(err).Error("checking active window")
+		return false
+	}
+
+	if !active {
+		logger.Tracef("Non-Match: Active-Window (%s)", reason)
+		return false
+	}
+
+	return true
+}
+
 func (r *Rule) allowExecuteBadgeBlacklist(logger *logrus.Entry, _ *irc.Message, _ *string, badges twitch.BadgeCollection, _ *fieldcollection.FieldCollection) bool {
 	for _, b := range r.DisableOn {
 		if badges.Has(b) {
@@ -302,13 +506,18 @@ func (r *Rule) allowExecuteBadgeBlacklist(logger *logrus.Entry, _ *irc.Message,
 	return true
 }
 
-func (r *Rule) allowExecuteBadgeWhitelist(_ *logrus.Entry, _ *irc.Message, _ *string, badges twitch.BadgeCollection, _ *fieldcollection.FieldCollection) bool {
+func (r *Rule) allowExecuteBadgeWhitelist(logger *logrus.Entry, _ *irc.Message, _ *string, badges twitch.BadgeCollection, _ *fieldcollection.FieldCollection) bool {
 	if len(r.EnableOn) == 0 {
 		// No match criteria set, does not speak against matching
 		return true
 	}
 
-	return slices.ContainsFunc(r.EnableOn, badges.Has)
+	if !slices.ContainsFunc(r.EnableOn, badges.Has) {
+		logger.Trace("Non-Match: Enable-Badge")
+		return false
+	}
+
+	return true
 }
 
 func (r *Rule) allowExecuteChannelCooldown(logger *logrus.Entry, m *irc.Message, _ *string, badges twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
@@ -327,7 +536,16 @@ func (r *Rule) allowExecuteChannelCooldown(logger *logrus.Entry, m *irc.Message,
 		return true
 	}
 
-	return slices.ContainsFunc(r.SkipCooldownFor, badges.Has)
+	return r.allowSkipCooldown(logger, badges, "Channel-Cooldown")
+}
+
+func (r *Rule) allowExecuteChannelUsageLimit(logger *logrus.Entry, m *irc.Message, _ *string, badges twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
+	if r.ChannelUsageLimit == nil || DeriveChannel(m, evtData) == "" {
+		// No match criteria set, does not speak against matching
+		return true
+	}
+
+	return r.allowUsageLimit(logger, badges, r.ChannelUsageLimit, "channel", DeriveChannel(m, evtData), "Channel-Usage-Limit")
 }
 
 func (r *Rule) allowExecuteChannelWhitelist(logger *logrus.Entry, m *irc.Message, _ *string, _ twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
@@ -437,8 +655,14 @@ func (r *Rule) allowExecuteEventMatch(logger *logrus.Entry, _ *irc.Message, even
 
 	var mE, gE string
 
-	if r.MatchEvent != nil {
+	switch {
+	case r.MatchEvent != nil:
 		mE = *r.MatchEvent
+
+	case r.MatchCron != nil:
+		// Rules triggered through a schedule only match the synthetic
+		// event created by the schedule
+		mE = EventTypeCron
 	}
 
 	if event != nil {
@@ -459,6 +683,25 @@ func (r *Rule) allowExecuteEventMatch(logger *logrus.Entry, _ *irc.Message, even
 	return false
 }
 
+func (r *Rule) allowExecuteFieldMatch(logger *logrus.Entry, _ *irc.Message, _ *string, _ twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
+	for i := range r.MatchFields {
+		fm := &r.MatchFields[i]
+
+		matches, err := fm.Matches(evtData)
+		if err != nil {
+			logger.WithError(err).Errorf("checking field %q", fm.Field)
+			return false
+		}
+
+		if !matches {
+			logger.Tracef("Non-Match: Field %q %s", fm.Field, fm.Operator)
+			return false
+		}
+	}
+
+	return true
+}
+
 func (r *Rule) allowExecuteMessageMatcherBlacklist(logger *logrus.Entry, m *irc.Message, _ *string, _ twitch.BadgeCollection, _ *fieldcollection.FieldCollection) bool {
 	if len(r.DisableOnMatchMessages) == 0 {
 		// No match criteria set, does not speak against matching
@@ -529,7 +772,16 @@ func (r *Rule) allowExecuteRuleCooldown(logger *logrus.Entry, _ *irc.Message, _
 		return true
 	}
 
-	return slices.ContainsFunc(r.SkipCooldownFor, badges.Has)
+	return r.allowSkipCooldown(logger, badges, "Rule-Cooldown")
+}
+
+func (r *Rule) allowExecuteRuleUsageLimit(logger *logrus.Entry, _ *irc.Message, _ *string, badges twitch.BadgeCollection, _ *fieldcollection.FieldCollection) bool {
+	if r.UsageLimit == nil {
+		// No match criteria set, does not speak against matching
+		return true
+	}
+
+	return r.allowUsageLimit(logger, badges, r.UsageLimit, "rule", "", "Rule-Usage-Limit")
 }
 
 func (r *Rule) allowExecuteUserCooldown(logger *logrus.Entry, m *irc.Message, _ *string, badges twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
@@ -548,7 +800,16 @@ func (r *Rule) allowExecuteUserCooldown(logger *logrus.Entry, m *irc.Message, _
 		return true
 	}
 
-	return slices.ContainsFunc(r.SkipCooldownFor, badges.Has)
+	return r.allowSkipCooldown(logger, badges, "User-Cooldown")
+}
+
+func (r *Rule) allowExecuteUserUsageLimit(logger *logrus.Entry, m *irc.Message, _ *string, badges twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
+	if r.UserUsageLimit == nil || DeriveUser(m, evtData) == "" {
+		// No match criteria set, does not speak against matching
+		return true
+	}
+
+	return r.allowUsageLimit(logger, badges, r.UserUsageLimit, "user", DeriveUser(m, evtData), "User-Usage-Limit")
 }
 
 func (r *Rule) allowExecuteUserWhitelist(logger *logrus.Entry, m *irc.Message, _ *string, _ twitch.BadgeCollection, evtData *fieldcollection.FieldCollection) bool {
@@ -565,6 +826,48 @@ func (r *Rule) allowExecuteUserWhitelist(logger *logrus.Entry, m *irc.Message, _
 	return true
 }
 
+func (r *Rule) allowSkipCooldown(logger *logrus.Entry, badges twitch.BadgeCollection, cooldown string) bool {
+	if !slices.ContainsFunc(r.SkipCooldownFor, badges.Has) {
+		logger.Tracef("Non-Match: %s", cooldown)
+		return false
+	}
+
+	return true
+}
+
+func (r *Rule) allowUsageLimit(logger *logrus.Entry, badges twitch.BadgeCollection, limit *RuleUsageLimit, scope, limiter, name string) bool {
+	slot, err := r.freeUsageLimitSlot(r.timerStore, limit, scope, limiter)
+	if err != nil {
+		logger.WithError(err).Errorf("checking %s", strings.ToLower(name))
+		return false
+	}
+
+	if slot >= 0 {
+		return true
+	}
+
+	return r.allowSkipCooldown(logger, badges, name)
+}
+
+func (r *Rule) consumeUsageLimit(timerStore TimerStore, limit *RuleUsageLimit, scope, limiter string) error {
+	slot, err := r.freeUsageLimitSlot(timerStore, limit, scope, limiter)
+	if err != nil {
+		return err
+	}
+
+	if slot < 0 {
+		// All tokens are in use, execution was allowed by skipping the
+		// limit so there is nothing to use up
+		return nil
+	}
+
+	if err = timerStore.AddCooldown(TimerTypeUsageLimit, r.usageLimitSlotKey(scope, limiter, slot), r.MatcherID(), time.Now().Add(limit.Window)); err != nil {
+		return fmt.Errorf("storing usage limit token: %w", err)
+	}
+
+	return nil
+}
+
 func (Rule) fileTypeFromRequest(remoteURL *url.URL, resp *http.Response) (string, error) {
 	switch path.Ext(remoteURL.Path) {
 	case ".json":
@@ -585,6 +888,23 @@ func (Rule) fileTypeFromRequest(remoteURL *url.URL, resp *http.Response) (string
 	return "", errors.New("no valid file type detected")
 }
 
+// freeUsageLimitSlot returns the index of the first unused token of
+// the usage limit or -1 if all tokens are in use
+func (r *Rule) freeUsageLimitSlot(timerStore TimerStore, limit *RuleUsageLimit, scope, limiter string) (int64, error) {
+	for slot := range limit.Count {
+		inUse, err := timerStore.InCooldown(TimerTypeUsageLimit, r.usageLimitSlotKey(scope, limiter, slot), r.MatcherID())
+		if err != nil {
+			return -1, fmt.Errorf("checking usage limit token: %w", err)
+		}
+
+		if !inUse {
+			return slot, nil
+		}
+	}
+
+	return -1, nil
+}
+
 func (r Rule) hash() string {
 	h, err := hashstructure.Hash(r, hashstructure.FormatV2, nil)
 	if err != nil {
@@ -592,3 +912,43 @@ func (r Rule) hash() string {
 	}
 	return fmt.Sprintf("hashstructure:%x", h)
 }
+
+func (r *Rule) matchers() []ruleMatcher {
+	return []ruleMatcher{
+		{"disable", r.allowExecuteDisable},
+		{"active_window", r.allowExecuteActiveWindow},
+		{"match_channels", r.allowExecuteChannelWhitelist},
+		{"match_users", r.allowExecuteUserWhitelist},
+		{"match_event", r.allowExecuteEventMatch},
+		{"match_message", r.allowExecuteMessageMatcherWhitelist},
+		{"match_fields", r.allowExecuteFieldMatch},
+		{"disable_on_match_messages", r.allowExecuteMessageMatcherBlacklist},
+		{"disable_on", r.allowExecuteBadgeBlacklist},
+		{"enable_on", r.allowExecuteBadgeWhitelist},
+		{"disable_on_permit", r.allowExecuteDisableOnPermit},
+		{"cooldown", r.allowExecuteRuleCooldown},
+		{"channel_cooldown", r.allowExecuteChannelCooldown},
+		{"user_cooldown", r.allowExecuteUserCooldown},
+		{"usage_limit", r.allowExecuteRuleUsageLimit},
+		{"channel_usage_limit", r.allowExecuteChannelUsageLimit},
+		{"user_usage_limit", r.allowExecuteUserUsageLimit},
+		{"disable_on_template", r.allowExecuteDisableOnTemplate},
+		{"disable_on_offline", r.allowExecuteDisableOnOffline},
+	}
+}
+
+func (Rule) usageLimitSlotKey(scope, limiter string, slot int64) string {
+	return fmt.Sprintf("%s:%s:%d", scope, limiter, slot)
+}
+
+func (h *ruleMatcherReasonHook) Fire(e *logrus.Entry) error {
+	reason := e.Message
+	if err, ok := e.Data[logrus.ErrorKey].(error); ok {
+		reason = fmt.Sprintf("%s: %s", reason, err)
+	}
+
+	h.reasons = append(h.reasons, reason)
+	return nil
+}
+
+func (*ruleMatcherReasonHook) Levels() []logrus.Level { return logrus.AllLevels }
@@ -0,0 +1,312 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ActiveWindowModuleName is the name of the module_config section to
+// configure the timezone of a channel in
+const ActiveWindowModuleName = "active_window"
+
+const (
+	activeWindowDateLayout     = "2006-01-02"
+	activeWindowDateTimeLayout = "2006-01-02 15:04"
+	activeWindowTimeLayout     = "15:04"
+)
+
+// RuleActiveWindow restricts the execution of a Rule to certain
+// weekdays, times of the day and / or an absolute date range. All
+// values are evaluated in the configured Timezone (IANA name like
+// "Europe/Berlin") overriding the timezone configured for the
+// channel or in the local time of the bot if none is set.
+type RuleActiveWindow struct {
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+
+	// Weekdays the rule is active on ("mon", "tue", ...)
+	Weekdays []string `json:"weekdays,omitempty" yaml:"weekdays,omitempty"`
+
+	// TimeFrom / TimeUntil define the time of day ("15:04") the rule
+	// is active in. If TimeUntil is before TimeFrom the window spans
+	// midnight and counts towards the weekday it started on. TimeFrom
+	// is inclusive, TimeUntil is exclusive.
+	TimeFrom  string `json:"time_from,omitempty" yaml:"time_from,omitempty"`
+	TimeUntil string `json:"time_until,omitempty" yaml:"time_until,omitempty"`
+
+	// DateFrom / DateUntil define an absolute range of dates
+	// ("2006-01-02" or "2006-01-02 15:04") the rule is active in.
+	// A DateUntil without time includes the whole given day.
+	DateFrom  string `json:"date_from,omitempty" yaml:"date_from,omitempty"`
+	DateUntil string `json:"date_until,omitempty" yaml:"date_until,omitempty"`
+
+	loc *time.Location
+}
+
+// ChannelTimezones contains the timezones configured for channels
+// (without leading #) to be used for active windows not defining
+// their own timezone
+type ChannelTimezones map[string]*time.Location
+
+var activeWindowWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// NewChannelTimezones loads the timezones configured for the channels
+// in the module config of the active window
+func NewChannelTimezones(mc ModuleConfig) (ChannelTimezones, error) {
+	out := make(ChannelTimezones)
+
+	for channel := range mc[ActiveWindowModuleName] {
+		tz := mc.GetChannelConfig(ActiveWindowModuleName, channel).MustString("timezone", new(""))
+		if tz == "" {
+			continue
+		}
+
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("loading timezone of channel %q: %w", channel, err)
+		}
+
+		out[channel] = loc
+	}
+
+	return out, nil
+}
+
+// Get returns the timezone configured for the channel, the default
+// timezone if the channel has none or nil if none is configured
+func (c ChannelTimezones) Get(channel string) *time.Location {
+	if loc, ok := c[strings.TrimLeft(channel, "#@")]; ok {
+		return loc
+	}
+
+	return c[DefaultConfigName]
+}
+
+// Compile loads the timezone of the window so it does not need to be
+// loaded on every check. As this modifies the window it must be done
+// before the window is used.
+func (w *RuleActiveWindow) Compile() (err error) {
+	if w.Timezone == "" {
+		return nil
+	}
+
+	if w.loc, err = time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("loading timezone: %w", err)
+	}
+
+	return nil
+}
+
+// IsActiveAt checks whether the given point in time is within the
+// window. The channelLocation is used if the window does not define
+// its own timezone. If not active, a reason for the mismatch is
+// returned.
+func (w RuleActiveWindow) IsActiveAt(t time.Time, channelLocation *time.Location) (active bool, reason string, err error) {
+	loc, err := w.location(channelLocation)
+	if err != nil {
+		return false, "", err
+	}
+
+	t = t.In(loc)
+	day := t.Weekday()
+
+	if w.TimeFrom != "" || w.TimeUntil != "" {
+		from, until, err := w.timeOfDayRange()
+		if err != nil {
+			return false, "", err
+		}
+
+		now := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+		var inRange bool
+		switch {
+		case from <= until:
+			inRange = now >= from && now < until
+
+		case now < until:
+			// Window spans midnight and we are in the part after
+			// midnight: it belongs to the day the window started
+			inRange = true
+			day = t.AddDate(0, 0, -1).Weekday()
+
+		default:
+			inRange = now >= from
+		}
+
+		if !inRange {
+			return false, fmt.Sprintf("time %s outside of active hours", t.Format(activeWindowTimeLayout)), nil
+		}
+	}
+
+	if len(w.Weekdays) > 0 {
+		weekdays, err := w.weekdays()
+		if err != nil {
+			return false, "", err
+		}
+
+		if !slices.Contains(weekdays, day) {
+			return false, fmt.Sprintf("weekday %s not active", day), nil
+		}
+	}
+
+	if w.DateFrom != "" {
+		from, err := w.parseDate(w.DateFrom, loc, false)
+		if err != nil {
+			return false, "", fmt.Errorf("parsing date_from: %w", err)
+		}
+
+		if t.Before(from) {
+			return false, "active date range not yet started", nil
+		}
+	}
+
+	if w.DateUntil != "" {
+		until, err := w.parseDate(w.DateUntil, loc, true)
+		if err != nil {
+			return false, "", fmt.Errorf("parsing date_until: %w", err)
+		}
+
+		if !t.Before(until) {
+			return false, "active date range has ended", nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// Validate checks all values of the window can be parsed
+func (w RuleActiveWindow) Validate() error {
+	loc, err := w.location(nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.weekdays(); err != nil {
+		return err
+	}
+
+	if w.TimeFrom != "" || w.TimeUntil != "" {
+		from, until, err := w.timeOfDayRange()
+		if err != nil {
+			return err
+		}
+
+		if from == until {
+			return errors.New("time_from and time_until must differ")
+		}
+	}
+
+	var from, until time.Time
+
+	if w.DateFrom != "" {
+		if from, err = w.parseDate(w.DateFrom, loc, false); err != nil {
+			return fmt.Errorf("parsing date_from: %w", err)
+		}
+	}
+
+	if w.DateUntil != "" {
+		if until, err = w.parseDate(w.DateUntil, loc, true); err != nil {
+			return fmt.Errorf("parsing date_until: %w", err)
+		}
+	}
+
+	if !from.IsZero() && !until.IsZero() && !until.After(from) {
+		return errors.New("date_until must be after date_from")
+	}
+
+	return nil
+}
+
+func (w RuleActiveWindow) location(channelLocation *time.Location) (*time.Location, error) {
+	switch {
+	case w.loc != nil:
+		return w.loc, nil
+
+	case w.Timezone != "":
+		// Not compiled: load without caching as the window might be
+		// used concurrently
+		loc, err := time.LoadLocation(w.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("loading timezone: %w", err)
+		}
+		return loc, nil
+
+	case channelLocation != nil:
+		return channelLocation, nil
+
+	default:
+		return time.Local, nil
+	}
+}
+
+func (RuleActiveWindow) parseDate(value string, loc *time.Location, endOfDay bool) (time.Time, error) {
+	if t, err := time.ParseInLocation(activeWindowDateTimeLayout, value, loc); err == nil {
+		return t, nil
+	}
+
+	t, err := time.ParseInLocation(activeWindowDateLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected %q or %q: %w", activeWindowDateLayout, activeWindowDateTimeLayout, err)
+	}
+
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
+func (w RuleActiveWindow) timeOfDayRange() (from, until time.Duration, err error) {
+	parse := func(field, value string, fallback time.Duration) (time.Duration, error) {
+		if value == "" {
+			return fallback, nil
+		}
+
+		t, err := time.Parse(activeWindowTimeLayout, value)
+		if err != nil {
+			return 0, fmt.Errorf("parsing %s: %w", field, err)
+		}
+
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+
+	if from, err = parse("time_from", w.TimeFrom, 0); err != nil {
+		return 0, 0, err
+	}
+
+	if until, err = parse("time_until", w.TimeUntil, 24*time.Hour); err != nil { //nolint:mnd // Full day
+		return 0, 0, err
+	}
+
+	return from, until, nil
+}
+
+func (w RuleActiveWindow) weekdays() ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, len(w.Weekdays))
+
+	for _, wd := range w.Weekdays {
+		key := strings.ToLower(wd)
+		if len(key) > 3 { //nolint:mnd // Allow full names by using the abbreviation
+			key = key[:3]
+		}
+
+		d, ok := activeWindowWeekdays[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", wd)
+		}
+
+		weekdays = append(weekdays, d)
+	}
+
+	return weekdays, nil
+}
@@ -0,0 +1,93 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleActiveWindow(t *testing.T) {
+	w := RuleActiveWindow{
+		Timezone:  "Europe/Berlin",
+		Weekdays:  []string{"fri", "Saturday"},
+		TimeFrom:  "22:00",
+		TimeUntil: "02:00",
+		DateFrom:  "2024-06-01",
+		DateUntil: "2024-06-30",
+	}
+	require.NoError(t, w.Validate())
+	require.NoError(t, w.Compile())
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for ts, exp := range map[string]bool{
+		"2024-06-07T20:30:00Z": true,  // Fri 22:30 CEST
+		"2024-06-07T19:59:00Z": false, // Fri 21:59 CEST
+		"2024-06-07T23:30:00Z": true,  // Sat 01:30 CEST
+		"2024-06-08T00:00:00Z": false, // Sat 02:00 CEST
+		"2024-06-09T20:30:00Z": false, // Sun 22:30 CEST
+		"2024-05-31T20:30:00Z": false, // Fri 22:30 CEST, before range
+		"2024-06-29T23:30:00Z": true,  // Sun 01:30 CEST, last day of range
+		"2024-07-05T20:30:00Z": false, // Fri 22:30 CEST, after range
+	} {
+		pt, err := time.Parse(time.RFC3339, ts)
+		require.NoError(t, err)
+
+		active, reason, err := w.IsActiveAt(pt, newYork)
+		require.NoError(t, err)
+		assert.Equal(t, exp, active, "%s (%s)", ts, reason)
+	}
+}
+
+func TestRuleActiveWindowChannelTimezone(t *testing.T) {
+	w := RuleActiveWindow{TimeFrom: "20:00", TimeUntil: "23:00"}
+	require.NoError(t, w.Validate())
+
+	tz, err := NewChannelTimezones(ModuleConfig{ActiveWindowModuleName: {
+		DefaultConfigName: fieldcollection.FieldCollectionFromData(map[string]any{"timezone": "Europe/Berlin"}),
+		"otherchannel":    fieldcollection.FieldCollectionFromData(map[string]any{"timezone": "America/New_York"}),
+	}})
+	require.NoError(t, err)
+
+	pt, err := time.Parse(time.RFC3339, "2024-06-07T20:30:00Z")
+	require.NoError(t, err)
+
+	// 22:30 CEST through the default timezone
+	active, _, err := w.IsActiveAt(pt, tz.Get("#mychannel"))
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	// 16:30 EDT through the channel timezone
+	active, _, err = w.IsActiveAt(pt, tz.Get("#otherchannel"))
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	// Rule timezone overrides the channel timezone
+	w.Timezone = "Europe/Berlin"
+	active, _, err = w.IsActiveAt(pt, tz.Get("#otherchannel"))
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	// Invalid channel timezones are reported
+	_, err = NewChannelTimezones(ModuleConfig{ActiveWindowModuleName: {
+		"mychannel": fieldcollection.FieldCollectionFromData(map[string]any{"timezone": "Mars/Olympus"}),
+	}})
+	assert.Error(t, err)
+}
+
+func TestRuleActiveWindowValidate(t *testing.T) {
+	for name, w := range map[string]RuleActiveWindow{
+		"timezone":   {Timezone: "Mars/Olympus"},
+		"weekday":    {Weekdays: []string{"someday"}},
+		"time":       {TimeFrom: "25:00"},
+		"date":       {DateFrom: "tomorrow"},
+		"date range": {DateFrom: "2024-06-02", DateUntil: "2024-06-01"},
+		"empty time": {TimeFrom: "10:00", TimeUntil: "10:00"},
+	} {
+		assert.Error(t, w.Validate(), name)
+	}
+}
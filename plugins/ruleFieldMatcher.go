@@ -0,0 +1,140 @@
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+)
+
+// Operators available for the RuleFieldMatcher
+const (
+	FieldMatcherOperatorEq     = "eq"
+	FieldMatcherOperatorExists = "exists"
+	FieldMatcherOperatorGt     = "gt"
+	FieldMatcherOperatorIn     = "in"
+	FieldMatcherOperatorLt     = "lt"
+	FieldMatcherOperatorNe     = "ne"
+	FieldMatcherOperatorRegex  = "regex"
+)
+
+// RuleFieldMatcher checks a single field of the event data against
+// the given value. All operators except "exists" fail in case the
+// field is not present in the event data.
+type RuleFieldMatcher struct {
+	Field    string   `json:"field" yaml:"field"`
+	Operator string   `json:"operator" yaml:"operator"`
+	Value    string   `json:"value,omitempty" yaml:"value,omitempty"`
+	Values   []string `json:"values,omitempty" yaml:"values,omitempty"`
+
+	regex *regexp.Regexp
+}
+
+// Compile pre-compiles the regular expression of the regex operator
+// so it does not need to be compiled on every match. As this modifies
+// the matcher it must be done before the matcher is used.
+func (f *RuleFieldMatcher) Compile() (err error) {
+	if f.Operator != FieldMatcherOperatorRegex {
+		return nil
+	}
+
+	if f.regex, err = regexp.Compile(f.Value); err != nil {
+		return fmt.Errorf("compiling regex: %w", err)
+	}
+
+	return nil
+}
+
+// Matches evaluates the matcher against the given event data
+func (f *RuleFieldMatcher) Matches(eventData *fieldcollection.FieldCollection) (bool, error) {
+	if f.Operator == FieldMatcherOperatorExists {
+		return eventData.HasAll(f.Field), nil
+	}
+
+	v, err := eventData.Get(f.Field)
+	if err != nil {
+		// Field is not set (or there is no event data at all)
+		return false, nil //nolint:nilerr // Missing fields do not match
+	}
+
+	sv := fmt.Sprint(v)
+
+	switch f.Operator {
+	case FieldMatcherOperatorEq:
+		return sv == f.Value, nil
+
+	case FieldMatcherOperatorGt, FieldMatcherOperatorLt:
+		fv, err := eventData.Float64(f.Field)
+		if err != nil {
+			// Not a number, can neither be greater nor lower
+			return false, nil //nolint:nilerr // Non-numeric fields do not match
+		}
+
+		cv, err := strconv.ParseFloat(f.Value, 64)
+		if err != nil {
+			return false, fmt.Errorf("parsing compare value: %w", err)
+		}
+
+		if f.Operator == FieldMatcherOperatorGt {
+			return fv > cv, nil
+		}
+		return fv < cv, nil
+
+	case FieldMatcherOperatorIn:
+		return slices.Contains(f.Values, sv), nil
+
+	case FieldMatcherOperatorNe:
+		return sv != f.Value, nil
+
+	case FieldMatcherOperatorRegex:
+		rex := f.regex
+		if rex == nil {
+			// Not pre-compiled: compile without caching as the matcher
+			// might be used concurrently
+			if rex, err = regexp.Compile(f.Value); err != nil {
+				return false, fmt.Errorf("compiling regex: %w", err)
+			}
+		}
+
+		return rex.MatchString(sv), nil
+
+	default:
+		return false, fmt.Errorf("unknown operator %q", f.Operator)
+	}
+}
+
+// Validate checks the matcher has all required values for the
+// given operator
+func (f RuleFieldMatcher) Validate() error {
+	if f.Field == "" {
+		return errors.New("field must not be empty")
+	}
+
+	switch f.Operator {
+	case FieldMatcherOperatorEq, FieldMatcherOperatorExists, FieldMatcherOperatorNe:
+		// No further requirements
+
+	case FieldMatcherOperatorGt, FieldMatcherOperatorLt:
+		if _, err := strconv.ParseFloat(f.Value, 64); err != nil {
+			return fmt.Errorf("value for %s must be numeric: %w", f.Operator, err)
+		}
+
+	case FieldMatcherOperatorIn:
+		if len(f.Values) == 0 {
+			return errors.New("values must not be empty for in")
+		}
+
+	case FieldMatcherOperatorRegex:
+		if _, err := regexp.Compile(f.Value); err != nil {
+			return fmt.Errorf("compiling regex: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown operator %q", f.Operator)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleFieldMatcher(t *testing.T) {
+	evtData := fieldcollection.FieldCollectionFromData(map[string]any{
+		"bits":      int64(500),
+		"message":   "Cheer500 take my bits",
+		"reward_id": "9e0f1a4c",
+	})
+
+	for _, tc := range []struct {
+		matcher RuleFieldMatcher
+		exp     bool
+	}{
+		{RuleFieldMatcher{Field: "reward_id", Operator: FieldMatcherOperatorEq, Value: "9e0f1a4c"}, true},
+		{RuleFieldMatcher{Field: "reward_id", Operator: FieldMatcherOperatorEq, Value: "other"}, false},
+		{RuleFieldMatcher{Field: "reward_id", Operator: FieldMatcherOperatorNe, Value: "other"}, true},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorEq, Value: "500"}, true},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorGt, Value: "499"}, true},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorGt, Value: "500"}, false},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorLt, Value: "1000"}, true},
+		{RuleFieldMatcher{Field: "message", Operator: FieldMatcherOperatorGt, Value: "1"}, false},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorIn, Values: []string{"100", "500"}}, true},
+		{RuleFieldMatcher{Field: "bits", Operator: FieldMatcherOperatorIn, Values: []string{"100"}}, false},
+		{RuleFieldMatcher{Field: "message", Operator: FieldMatcherOperatorRegex, Value: `^Cheer\d+`}, true},
+		{RuleFieldMatcher{Field: "message", Operator: FieldMatcherOperatorRegex, Value: `^Kappa`}, false},
+		{RuleFieldMatcher{Field: "reward_id", Operator: FieldMatcherOperatorExists}, true},
+		{RuleFieldMatcher{Field: "user", Operator: FieldMatcherOperatorExists}, false},
+		{RuleFieldMatcher{Field: "user", Operator: FieldMatcherOperatorNe, Value: "amy"}, false},
+	} {
+		require.NoError(t, tc.matcher.Validate())
+
+		// Matchers not being compiled compile regexps on every match
+		res, err := tc.matcher.Matches(evtData)
+		require.NoError(t, err)
+		assert.Equal(t, tc.exp, res, "%s %s %q", tc.matcher.Field, tc.matcher.Operator, tc.matcher.Value)
+
+		require.NoError(t, tc.matcher.Compile())
+
+		res, err = tc.matcher.Matches(evtData)
+		require.NoError(t, err)
+		assert.Equal(t, tc.exp, res, "compiled %s %s %q", tc.matcher.Field, tc.matcher.Operator, tc.matcher.Value)
+	}
+}
+
+func TestRuleFieldMatcherValidate(t *testing.T) {
+	for name, fm := range map[string]RuleFieldMatcher{
+		"no field":        {Operator: FieldMatcherOperatorExists},
+		"unknown op":      {Field: "bits", Operator: "gte"},
+		"non-numeric gt":  {Field: "bits", Operator: FieldMatcherOperatorGt, Value: "many"},
+		"empty in":        {Field: "bits", Operator: FieldMatcherOperatorIn},
+		"invalid regex":   {Field: "message", Operator: FieldMatcherOperatorRegex, Value: "("},
+		"empty lt number": {Field: "bits", Operator: FieldMatcherOperatorLt},
+	} {
+		assert.Error(t, fm.Validate(), name)
+	}
+}
@@ -165,6 +165,32 @@ func TestAllowExecuteEventWhitelist(t *testing.T) {
 	}
 }
 
+func TestAllowExecuteEventMatchCron(t *testing.T) {
+	r := &Rule{MatchCron: func(s string) *string { return &s }("@hourly"), MatchChannels: []string{"#mychannel"}}
+	require.NoError(t, r.Validate(func(string) error { return nil }))
+
+	for evt, exp := range map[string]bool{
+		"":            false,
+		"follow":      false,
+		EventTypeCron: true,
+	} {
+		if res := r.allowExecuteEventMatch(testLogger, nil, &evt, twitch.BadgeCollection{}, nil); exp != res {
+			t.Errorf("Event %q yield unexpected result: exp=%v res=%v", evt, exp, res)
+		}
+	}
+
+	r.MatchEvent = func(s string) *string { return &s }("follow")
+	require.Error(t, r.Validate(func(string) error { return nil }), "cron with event")
+
+	r.MatchEvent = nil
+	r.MatchChannels = nil
+	require.Error(t, r.Validate(func(string) error { return nil }), "cron without channels")
+
+	r.MatchChannels = []string{"#mychannel"}
+	r.MatchCron = func(s string) *string { return &s }("not a cron")
+	require.Error(t, r.Validate(func(string) error { return nil }), "invalid cron")
+}
+
 func TestAllowExecuteMessageMatcherBlacklist(t *testing.T) {
 	r := &Rule{DisableOnMatchMessages: []string{`^!disable`}}
 
@@ -238,6 +264,41 @@ func TestAllowExecuteUserCooldown(t *testing.T) {
 	}
 }
 
+func TestAllowExecuteUserUsageLimit(t *testing.T) {
+	r := &Rule{UserUsageLimit: &RuleUsageLimit{Count: 2, Window: time.Minute}, SkipCooldownFor: []string{twitch.BadgeBroadcaster}}
+	c1 := irc.MustParseMessage(":ben!ben@foo.example.com PRIVMSG #mychannel :Testing")
+	c2 := irc.MustParseMessage(":amy!amy@foo.example.com PRIVMSG #mychannel :Testing")
+
+	ts := newTestTimerStore()
+	r.timerStore = ts
+
+	for i := range 2 {
+		if !r.allowExecuteUserUsageLimit(testLogger, c1, nil, twitch.BadgeCollection{}, nil) {
+			t.Errorf("Call %d within limit was not allowed", i)
+		}
+		r.SetCooldown(ts, c1, nil)
+	}
+
+	if r.allowExecuteUserUsageLimit(testLogger, c1, nil, twitch.BadgeCollection{}, nil) {
+		t.Error("Call after limit was used up was allowed")
+	}
+
+	if !r.allowExecuteUserUsageLimit(testLogger, c1, nil, twitch.BadgeCollection{twitch.BadgeBroadcaster: testBadgeLevel0}, nil) {
+		t.Error("Call after limit was used up with skip badge was not allowed")
+	}
+
+	if !r.allowExecuteUserUsageLimit(testLogger, c2, nil, twitch.BadgeCollection{}, nil) {
+		t.Error("Call with different user was not allowed")
+	}
+
+	// Expire the first token
+	ts.timers[ts.getCooldownTimerKey(TimerTypeUsageLimit, r.usageLimitSlotKey("user", c1.User, 0), r.MatcherID())] = time.Now().Add(-time.Second)
+
+	if !r.allowExecuteUserUsageLimit(testLogger, c1, nil, twitch.BadgeCollection{}, nil) {
+		t.Error("Call after token was refilled was not allowed")
+	}
+}
+
 func TestAllowExecuteUserWhitelist(t *testing.T) {
 	r := &Rule{MatchUsers: []string{"amy"}}
 
@@ -250,3 +311,26 @@ func TestAllowExecuteUserWhitelist(t *testing.T) {
 		}
 	}
 }
+
+func TestExplain(t *testing.T) {
+	r := &Rule{
+		MatchChannels: []string{"#mychannel"},
+		MatchMessage:  func(s string) *string { return &s }(`^!test`),
+		EnableOn:      []string{twitch.BadgeModerator},
+	}
+
+	m := irc.MustParseMessage("@badges=subscriber/12 :amy!amy@foo.example.com PRIVMSG #mychannel :!test")
+	matches, verdicts := r.Explain(m, nil, newTestTimerStore(), nil, nil, nil, nil)
+
+	require.False(t, matches)
+	require.Len(t, verdicts, len(r.matchers()))
+
+	failed := map[string]string{}
+	for _, v := range verdicts {
+		if !v.Passed {
+			failed[v.Matcher] = v.Reason
+		}
+	}
+
+	require.Equal(t, map[string]string{"enable_on": "Non-Match: Enable-Badge"}, failed)
+}
@@ -11,6 +11,7 @@ import (
 const (
 	TimerTypePermit TimerType = iota
 	TimerTypeCooldown
+	TimerTypeUsageLimit
 )
 
 type (
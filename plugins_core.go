@@ -13,10 +13,15 @@ import (
 	"gopkg.in/irc.v4"
 
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/announce"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/automod"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/ban"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/blockedterms"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/callactions"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/chatsettings"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/clip"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/clipdetector"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/commercial"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/conditional"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/counter"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/delay"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/deleteactor"
@@ -30,20 +35,27 @@ import (
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/modchannel"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/nuke"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/pin"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/poll"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/prediction"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/punish"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/quotedb"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/raw"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/redemption"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/respond"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/reward"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/shield"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/shoutout"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/spotify"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/stopexec"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/timeout"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/unbanrequest"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/unpin"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/variables"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/vip"
+	"github.com/Luzifer/twitch-bot/v3/internal/actors/warn"
 	"github.com/Luzifer/twitch-bot/v3/internal/actors/whisper"
 	"github.com/Luzifer/twitch-bot/v3/internal/apimodules/customevent"
+	"github.com/Luzifer/twitch-bot/v3/internal/apimodules/eventlog"
 	"github.com/Luzifer/twitch-bot/v3/internal/apimodules/kofi"
 	"github.com/Luzifer/twitch-bot/v3/internal/apimodules/msgformat"
 	"github.com/Luzifer/twitch-bot/v3/internal/apimodules/overlays"
@@ -72,10 +84,15 @@ var (
 	corePluginRegistrations = []plugins.RegisterFunc{
 		// Actors
 		announce.Register,
+		automod.Register,
 		ban.Register,
+		blockedterms.Register,
+		callactions.Register,
+		chatsettings.Register,
 		clip.Register,
 		clipdetector.Register,
 		commercial.Register,
+		conditional.Register,
 		counter.Register,
 		delay.Register,
 		deleteactor.Register,
@@ -89,17 +106,23 @@ var (
 		modchannel.Register,
 		nuke.Register,
 		pin.Register,
+		poll.Register,
+		prediction.Register,
 		punish.Register,
 		quotedb.Register,
 		raw.Register,
+		redemption.Register,
 		respond.Register,
+		reward.Register,
 		shield.Register,
 		shoutout.Register,
 		stopexec.Register,
 		timeout.Register,
+		unbanrequest.Register,
 		unpin.Register,
 		variables.Register,
 		vip.Register,
+		warn.Register,
 		whisper.Register,
 
 		// Template functions
@@ -116,6 +139,7 @@ var (
 
 		// API-only modules
 		customevent.Register,
+		eventlog.Register,
 		kofi.Register,
 		msgformat.Register,
 		overlays.Register,
@@ -183,9 +207,11 @@ func getRegistrationArguments() plugins.RegistrationArguments {
 	return plugins.RegistrationArguments{
 		FormatMessage:              formatMessage,
 		FrontendNotify:             func(mt string) { frontendNotifyHooks.Ping(mt) },
+		GetActionSet:               getActionSet,
 		GetBaseURL:                 func() string { return cfg.BaseURL },
 		GetDatabaseConnector:       func() database.Connector { return db },
 		GetLogger:                  func(moduleName string) *logrus.Entry { return logrus.WithField("module", moduleName) },
+		ExecuteActions:             executeActions,
 		GetTwitchClient:            func() *twitch.Client { return twitchClient },
 		HasAnyPermissionForChannel: accessService.HasAnyPermissionForChannel,
 		HasPermissionForChannel:    accessService.HasPermissionsForChannel,
@@ -199,6 +225,7 @@ func getRegistrationArguments() plugins.RegistrationArguments {
 		RegisterRawMessageHandler:  registerRawMessageHandler,
 		RegisterTemplateFunction:   tplFuncs.Register,
 		SendMessage:                sendMessage,
+		ValidateActions:            validateActions,
 		ValidateToken:              authService.ValidateTokenFor,
 
 		CreateEvent: func(evt string, eventData *fieldcollection.FieldCollection) error {
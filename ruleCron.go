@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+var (
+	ruleCronEntries     []cron.EntryID
+	ruleCronEntriesLock sync.Mutex
+)
+
+// executeCronRule triggers the given rule once for every channel it
+// is configured for using the synthetic cron event
+func executeCronRule(r *plugins.Rule) {
+	if ircHdl == nil {
+		log.WithField("rule", r.MatcherID()).Warn("Skipping scheduled rule: bot is not connected to chat")
+		return
+	}
+
+	configLock.RLock()
+	channelTimezones := config.channelTimezones
+	configLock.RUnlock()
+
+	for _, channel := range r.MatchChannels {
+		eventData := fieldcollection.FieldCollectionFromData(map[string]any{
+			"channel":  "#" + strings.TrimLeft(channel, "#"),
+			"schedule": *r.MatchCron,
+			"time":     time.Now(),
+		})
+
+		if !r.Matches(nil, eventTypeCron, timerService, formatMessage, twitchClient, channelTimezones, eventData) {
+			continue
+		}
+
+		go handleMessageRuleExecution(ircHdl.Client(), nil, eventTypeCron, r, eventData)
+	}
+}
+
+// updateRuleCrons replaces the schedules of all previously registered
+// rules with the schedules of the given rules
+func updateRuleCrons(rules []*plugins.Rule) {
+	ruleCronEntriesLock.Lock()
+	defer ruleCronEntriesLock.Unlock()
+
+	for _, id := range ruleCronEntries {
+		cronService.Remove(id)
+	}
+	ruleCronEntries = nil
+
+	for _, r := range rules {
+		if r.MatchCron == nil {
+			continue
+		}
+
+		logger := log.WithField("rule", r.MatcherID())
+
+		sched, err := cronParser.Parse(*r.MatchCron)
+		if err != nil {
+			// Should have been caught by config validation
+			logger.WithError(err).Error("Unable to parse rule schedule")
+			continue
+		}
+
+		ruleCronEntries = append(ruleCronEntries, cronService.Schedule(sched, cron.FuncJob(func() { executeCronRule(r) })))
+	}
+}
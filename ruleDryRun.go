@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"gopkg.in/irc.v4"
+
+	"github.com/Luzifer/twitch-bot/v3/plugins"
+)
+
+type (
+	ruleDryRunRequest struct {
+		Badges     []string       `json:"badges,omitempty"`
+		Channel    string         `json:"channel,omitempty"`
+		Event      *string        `json:"event,omitempty"`
+		Fields     map[string]any `json:"fields,omitempty"`
+		Message    string         `json:"message,omitempty"`
+		RawMessage string         `json:"raw_message,omitempty"`
+		User       string         `json:"user,omitempty"`
+	}
+
+	ruleDryRunResult struct {
+		Description string                       `json:"description,omitempty"`
+		Matches     bool                         `json:"matches"`
+		Matchers    []plugins.RuleMatcherVerdict `json:"matchers"`
+		UUID        string                       `json:"uuid"`
+	}
+)
+
+// dryRunRules evaluates all configured rules against the synthetic
+// message / event described in the request without executing any
+// actions or setting any cooldowns
+func dryRunRules(req ruleDryRunRequest) ([]ruleDryRunResult, error) {
+	var (
+		err error
+		m   *irc.Message
+	)
+
+	switch {
+	case req.Message != "" || req.RawMessage != "":
+		if m, err = req.ircMessage(); err != nil {
+			return nil, fmt.Errorf("building message: %w", err)
+		}
+
+	case req.Event == nil:
+		return nil, errors.New("neither message nor event given")
+
+	default:
+		// Events not originating from chat are handled without message
+	}
+
+	eventData := fieldcollection.FieldCollectionFromData(req.Fields)
+	if req.Channel != "" && !eventData.HasAll("channel") {
+		eventData.Set("channel", "#"+strings.TrimLeft(req.Channel, "#"))
+	}
+	if req.User != "" && !eventData.HasAll("user") {
+		eventData.Set("user", strings.ToLower(req.User))
+	}
+
+	// Matchers might format templates which take the config lock
+	// themselves so the rules must not be explained while holding it
+	configLock.RLock()
+	rules := slices.Clone(config.Rules)
+	channelTimezones := config.channelTimezones
+	configLock.RUnlock()
+
+	out := make([]ruleDryRunResult, 0, len(rules))
+	for _, r := range rules {
+		matches, verdicts := r.Explain(m, req.Event, timerService, formatMessage, twitchClient, channelTimezones, eventData)
+		out = append(out, ruleDryRunResult{
+			Description: r.Description,
+			Matches:     matches,
+			Matchers:    verdicts,
+			UUID:        r.MatcherID(),
+		})
+	}
+
+	return out, nil
+}
+
+func (r ruleDryRunRequest) ircMessage() (*irc.Message, error) {
+	if r.RawMessage != "" {
+		m, err := irc.ParseMessage(r.RawMessage)
+		if err != nil {
+			return nil, fmt.Errorf("parsing raw message: %w", err)
+		}
+		return m, nil
+	}
+
+	if r.Channel == "" || r.User == "" {
+		return nil, errors.New("message needs channel and user")
+	}
+
+	var badges []string
+	for _, b := range r.Badges {
+		if !strings.Contains(b, "/") {
+			b += "/1"
+		}
+		badges = append(badges, b)
+	}
+
+	user := strings.ToLower(r.User)
+
+	return &irc.Message{
+		Tags: irc.Tags{
+			"badges":       strings.Join(badges, ","),
+			"display-name": r.User,
+		},
+		Prefix: &irc.Prefix{
+			Name: user,
+			User: user,
+			Host: user + ".tmi.twitch.tv",
+		},
+		Command: "PRIVMSG",
+		Params:  []string{"#" + strings.TrimLeft(r.Channel, "#"), r.Message},
+	}, nil
+}
@@ -4,32 +4,48 @@ import "github.com/Luzifer/twitch-bot/v3/pkg/twitch"
 
 var (
 	channelExtendedScopes = map[string]string{
+		twitch.ScopeBitsRead:                     "see cheers (bits)",
 		twitch.ScopeChannelBot:                   "access chat without moderator status",
 		twitch.ScopeChannelEditCommercial:        "run commercial",
 		twitch.ScopeChannelManageBroadcast:       "modify category / title, create markers",
 		twitch.ScopeChannelManagePolls:           "manage polls",
 		twitch.ScopeChannelManagePredictions:     "manage predictions",
+		twitch.ScopeChannelManageRedemptions:     "manage channel-point rewards / redemptions",
 		twitch.ScopeChannelManageRaids:           "start raids",
 		twitch.ScopeChannelManageVIPS:            "manage VIPs",
 		twitch.ScopeChannelReadAds:               "see when an ad-break starts",
 		twitch.ScopeChannelReadHypetrain:         "see Hype-Train events",
+		twitch.ScopeChannelReadPredictions:       "see predictions",
 		twitch.ScopeChannelReadRedemptions:       "see channel-point redemptions",
-		twitch.ScopeChannelReadSubscriptions:     "see subscribed users / sub count / points",
+		twitch.ScopeChannelReadSubscriptions:     "see subscribed users / sub count / points / sub events",
 		twitch.ScopeClipsEdit:                    "create clips on behalf of this user",
+		twitch.ScopeModeratorManageAutomod:       "see / approve / deny messages held by AutoMod",
+		twitch.ScopeModeratorReadBannedUsers:     "see bans / timeouts (moderator actions)",
+		twitch.ScopeModeratorReadBlockedTerms:    "see blocked terms (moderator actions)",
+		twitch.ScopeModeratorReadChatMessages:    "see deleted messages (moderator actions)",
+		twitch.ScopeModeratorReadChatSettings:    "see chat settings (moderator actions)",
 		twitch.ScopeModeratorReadFollowers:       "see who follows this channel",
+		twitch.ScopeModeratorReadModerators:      "see moderators (moderator actions)",
 		twitch.ScopeModeratorReadShoutouts:       "see shoutouts created / received",
 		twitch.ScopeModeratorReadSuspiciousUsers: "see users marked suspicious / restricted",
+		twitch.ScopeModeratorReadUnbanRequests:   "see unban requests (moderator actions)",
+		twitch.ScopeModeratorReadVIPs:            "see VIPs (moderator actions)",
+		twitch.ScopeModeratorReadWarnings:        "see warnings (moderator actions)",
 		twitch.ScopeUserManageWhispers:           "send whispers on behalf of this user",
 	}
 
 	botDefaultScopes = []string{
 		// API Scopes
 		twitch.ScopeModeratorManageAnnoucements,
+		twitch.ScopeModeratorManageAutomod,
 		twitch.ScopeModeratorManageBannedUsers,
+		twitch.ScopeModeratorManageBlockedTerms,
 		twitch.ScopeModeratorManageChatMessages,
 		twitch.ScopeModeratorManageChatSettings,
 		twitch.ScopeModeratorManageShieldMode,
 		twitch.ScopeModeratorManageShoutouts,
+		twitch.ScopeModeratorManageUnbanRequests,
+		twitch.ScopeModeratorManageWarnings,
 		twitch.ScopeModeratorReadFollowers,
 		twitch.ScopeUserBot,
 		twitch.ScopeUserWriteChat,
@@ -1,5 +1,6 @@
 import { createRouter, createWebHashHistory } from 'vue-router'
 
+import ActionSets from './views/actionSets.vue'
 import Automessages from './views/automessages.vue'
 import GeneralConfig from './views/generalConfig.vue'
 import Raffle from './views/raffle.vue'
@@ -16,6 +17,11 @@ const routes = [
     name: 'edit-automessages',
     path: '/automessages',
   },
+  {
+    component: ActionSets,
+    name: 'edit-action-sets',
+    path: '/action-sets',
+  },
   {
     component: Raffle,
     name: 'raffle',
@@ -37,6 +37,17 @@ export interface AuthURLsResponse {
   update_channel_scopes: string
 }
 
+export interface EventSubTopicStatus {
+  condition: Record<string, string>
+  cost: number
+  last_error?: string
+  optional: boolean
+  state: 'failed' | 'pending' | 'retrying' | 'subscribed'
+  topic: string
+  updated_at: string
+  version: string
+}
+
 export interface GeneralConfig {
   bot_editors: string[]
   bot_name?: string
@@ -52,7 +63,7 @@ export interface TwitchUser {
   profile_image_url: string
 }
 
-export type ActionDocumentationFieldType = 'bool' | 'duration' | 'int64' | 'string' | 'stringslice'
+export type ActionDocumentationFieldType = 'actionlist' | 'bool' | 'duration' | 'int64' | 'string' | 'stringslice'
 
 export interface ActionDocumentationField {
   default: string
@@ -75,12 +86,21 @@ export interface ActionDocumentation {
 
 export interface RuleAction {
   attributes: Record<string, unknown>
+  on_error?: RuleAction[]
+  retry?: RuleActionRetry
   type: string
 }
 
+export interface RuleActionRetry {
+  count: number
+  delay?: number | string
+}
+
 export interface Rule {
   actions?: RuleAction[]
+  active_window?: RuleActiveWindow
   channel_cooldown?: number | string
+  channel_usage_limit?: RuleUsageLimit
   cooldown?: number | string
   description?: string
   disable?: boolean
@@ -90,16 +110,77 @@ export interface Rule {
   disable_on_permit?: boolean
   disable_on_template?: string
   enable_on?: string[]
+  group?: string
   match_channels?: string[]
+  match_cron?: string
   match_event?: string
+  match_fields?: RuleFieldMatcher[]
   match_message?: string | null
   match_users?: string[]
+  priority?: number
   skip_cooldown_for?: string[]
   subscribe_from?: string
+  usage_limit?: RuleUsageLimit
   user_cooldown?: number | string
+  user_usage_limit?: RuleUsageLimit
   uuid?: string
 }
 
+export interface RuleActiveWindow {
+  date_from?: string
+  date_until?: string
+  time_from?: string
+  time_until?: string
+  timezone?: string
+  weekdays?: string[]
+}
+
+export type RuleFieldMatcherOperator = 'eq' | 'exists' | 'gt' | 'in' | 'lt' | 'ne' | 'regex'
+
+export interface RuleFieldMatcher {
+  field: string
+  operator: RuleFieldMatcherOperator
+  value?: string
+  values?: string[]
+}
+
+export interface RuleUsageLimit {
+  count: number
+  window: number | string
+}
+
+export interface RuleMatcherVerdict {
+  matcher: string
+  passed: boolean
+  reason?: string
+}
+
+export interface RuleDryRunResult {
+  description?: string
+  matchers: RuleMatcherVerdict[]
+  matches: boolean
+  uuid: string
+}
+
+export interface RuleActionExecution {
+  duration: number
+  error?: string
+  stopped?: boolean
+  type: string
+}
+
+export interface RuleExecution {
+  actions: RuleActionExecution[]
+  channel?: string
+  cooldown_set: boolean
+  error?: string
+  event?: string
+  executed_at: string
+  id: number
+  rule_uuid: string
+  user?: string
+}
+
 export interface AutoMessage {
   channel?: string
   cron?: string
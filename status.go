@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
 	"github.com/Luzifer/twitch-bot/v3/plugins"
 )
 
@@ -17,8 +20,10 @@ const statusIRCMessageReceivedTimeout = 5 * time.Minute
 
 type (
 	statusResponse struct {
-		Checks               []statusResponseCheck `json:"checks"`
-		OverallStatusSuccess bool                  `json:"overall_status_success"`
+		Checks               []statusResponseCheck          `json:"checks"`
+		OverallStatusSuccess bool                           `json:"overall_status_success"`
+		TwitchAPIQueueDepth  int                            `json:"twitch_api_queue_depth"`
+		TwitchAPIRateLimits  []twitch.RateLimitBucketStatus `json:"twitch_api_rate_limits"`
 	}
 
 	statusResponseCheck struct {
@@ -27,7 +32,8 @@ type (
 		Success     bool   `json:"success"`
 		Error       string `json:"error,omitempty"`
 
-		checkFn func() error
+		checkFn     func() error
+		nonCritical bool
 	}
 )
 
@@ -64,9 +70,11 @@ func handleStatusRequest(w http.ResponseWriter, r *http.Request) {
 
 	output := statusResponse{
 		OverallStatusSuccess: true,
+		TwitchAPIQueueDepth:  twitch.RateLimitQueueDepth(),
+		TwitchAPIRateLimits:  twitch.RateLimitStatus(),
 	}
 
-	for _, chk := range []statusResponseCheck{
+	for _, chk := range append([]statusResponseCheck{
 		{
 			Name:        "Chat connection alive",
 			Description: fmt.Sprintf("Chat connection received a message in last %s", statusIRCMessageReceivedTimeout),
@@ -94,7 +102,7 @@ func handleStatusRequest(w http.ResponseWriter, r *http.Request) {
 				return nil
 			},
 		},
-	} {
+	}, getEventSubStatusChecks()...) {
 		err := chk.checkFn()
 		if err != nil {
 			chk.Error = err.Error()
@@ -102,7 +110,7 @@ func handleStatusRequest(w http.ResponseWriter, r *http.Request) {
 		chk.Success = err == nil
 
 		output.Checks = append(output.Checks, chk)
-		output.OverallStatusSuccess = output.OverallStatusSuccess && chk.Success
+		output.OverallStatusSuccess = output.OverallStatusSuccess && (chk.Success || chk.nonCritical)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -115,3 +123,55 @@ func handleStatusRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// getEventSubStatusChecks creates one check per channel having
+// EventSub topics. Failing optional topics are reported but do not
+// affect the overall status.
+func getEventSubStatusChecks() (checks []statusResponseCheck) {
+	if twitchWatch == nil {
+		return nil
+	}
+
+	status := twitchWatch.EventSubStatus()
+
+	channels := make([]string, 0, len(status))
+	for channel := range status {
+		channels = append(channels, channel)
+	}
+	slices.Sort(channels)
+
+	for _, channel := range channels {
+		var (
+			errs        []string
+			nonCritical = true
+		)
+
+		if len(status[channel]) == 0 {
+			// Channel is not authorized or not yet initialized
+			continue
+		}
+
+		for _, topic := range status[channel] {
+			if topic.IsHealthy() {
+				continue
+			}
+
+			errs = append(errs, fmt.Sprintf("%s/%s %s: %s", topic.Topic, topic.Version, topic.State, topic.LastError))
+			nonCritical = nonCritical && topic.Optional
+		}
+
+		checks = append(checks, statusResponseCheck{
+			Name:        fmt.Sprintf("EventSub #%s", channel),
+			Description: fmt.Sprintf("All EventSub topics for #%s are subscribed", channel),
+			checkFn: func() error {
+				if len(errs) > 0 {
+					return errors.New(strings.Join(errs, "; "))
+				}
+				return nil
+			},
+			nonCritical: nonCritical,
+		})
+	}
+
+	return checks
+}
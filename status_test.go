@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Luzifer/twitch-bot/v3/pkg/twitch"
+)
+
+func TestGetEventSubStatusChecks(t *testing.T) {
+	prevWatch := twitchWatch
+	t.Cleanup(func() { twitchWatch = prevWatch })
+
+	var (
+		subscribed = twitch.EventSubTopicStatus{Topic: "channel.follow", Version: "2", State: twitch.EventSubTopicStateSubscribed}
+		pending    = twitch.EventSubTopicStatus{Topic: "channel.raid", Version: "1", State: twitch.EventSubTopicStatePending, Optional: true}
+		optFailed  = twitch.EventSubTopicStatus{Topic: "channel.ad_break.begin", Version: "1", State: twitch.EventSubTopicStateFailed, Optional: true, LastError: "forbidden"}
+		retrying   = twitch.EventSubTopicStatus{Topic: "channel.update", Version: "2", State: twitch.EventSubTopicStateRetrying, LastError: "timeout"}
+	)
+
+	twitchWatch = newTwitchWatcher()
+	for channel, status := range map[string][]twitch.EventSubTopicStatus{
+		"healthy":  {subscribed, pending},
+		"optional": {subscribed, optFailed},
+		"critical": {optFailed, retrying},
+		"unauthed": nil,
+	} {
+		twitchWatch.ChannelStatus[channel] = &twitchChannelState{lastESCStatus: status}
+	}
+
+	checks := getEventSubStatusChecks()
+	require.Len(t, checks, 3)
+
+	for i, exp := range []struct {
+		name        string
+		expErr      string
+		nonCritical bool
+	}{
+		{"EventSub #critical", "channel.ad_break.begin/1 failed: forbidden; channel.update/2 retrying: timeout", false},
+		{"EventSub #healthy", "", true},
+		{"EventSub #optional", "channel.ad_break.begin/1 failed: forbidden", true},
+	} {
+		assert.Equal(t, exp.name, checks[i].Name)
+		assert.Equal(t, exp.nonCritical, checks[i].nonCritical, exp.name)
+
+		if err := checks[i].checkFn(); exp.expErr == "" {
+			assert.NoError(t, err, exp.name)
+		} else {
+			assert.EqualError(t, err, exp.expErr, exp.name)
+		}
+	}
+
+	twitchWatch = nil
+	assert.Empty(t, getEventSubStatusChecks())
+}
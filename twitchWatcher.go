@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/Luzifer/go_helpers/fieldcollection"
 	log "github.com/sirupsen/logrus"
@@ -21,9 +24,17 @@ type (
 		Condition      twitch.EventSubCondition
 		RequiredScopes []string
 		AnyScope       bool
-		Hook           func(json.RawMessage) error
-		Version        string
-		Optional       bool
+		// ScopeGroups must all be satisfied, each by any of its scopes
+		ScopeGroups [][]string
+		Hook        func(json.RawMessage) error
+		Version     string
+		Optional    bool
+	}
+
+	eventSubClient interface {
+		Close()
+		Run() error
+		TopicStatus() []twitch.EventSubTopicStatus
 	}
 
 	twitchChannelState struct {
@@ -32,7 +43,8 @@ type (
 		Title    string
 
 		isInitialized bool
-		esc           *twitch.EventSubSocketClient
+		esc           eventSubClient
+		lastESCStatus []twitch.EventSubTopicStatus
 	}
 
 	twitchWatcher struct {
@@ -42,11 +54,31 @@ type (
 	}
 )
 
+// CloseESC closes the EventSub client and keeps its last state. As
+// the state is read by other routines, the lock of the twitchWatcher
+// must be held when calling this.
 func (t *twitchChannelState) CloseESC() {
+	if t.esc == nil {
+		return
+	}
+
 	t.esc.Close()
+	// Keep the last known topic state to report why the client failed
+	t.lastESCStatus = t.esc.TopicStatus()
 	t.esc = nil
 }
 
+// EventSubStatus returns the state of the EventSub topics: for a
+// running client its current state, otherwise the last state seen
+// before the client was closed
+func (t *twitchChannelState) EventSubStatus() []twitch.EventSubTopicStatus {
+	if t.esc != nil {
+		return t.esc.TopicStatus()
+	}
+
+	return t.lastESCStatus
+}
+
 func (t twitchChannelState) Equals(c twitchChannelState) bool {
 	return t.Category == c.Category &&
 		t.IsLive == c.IsLive &&
@@ -101,6 +133,20 @@ func (t *twitchWatcher) Check() {
 	}
 }
 
+// EventSubStatus returns the state of the EventSub topics for all
+// watched channels
+func (t *twitchWatcher) EventSubStatus() map[string][]twitch.EventSubTopicStatus {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	out := make(map[string][]twitch.EventSubTopicStatus, len(t.ChannelStatus))
+	for channel, status := range t.ChannelStatus {
+		out[channel] = status.EventSubStatus()
+	}
+
+	return out
+}
+
 func (t *twitchWatcher) RemoveChannel(channel string) error {
 	t.lock.Lock()
 	defer t.lock.Unlock()
@@ -116,6 +162,22 @@ func (t *twitchWatcher) RemoveChannel(channel string) error {
 //nolint:funlen // Just a collection of topics
 func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration {
 	return []topicRegistration{
+		{
+			Topic:          twitch.EventSubEventTypeAutomodMessageHold,
+			Version:        twitch.EventSubTopicVersion2,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorManageAutomod},
+			Hook:           t.handleEventSubAutomodMessage(eventTypeAutomodHold),
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeAutomodMessageUpdate,
+			Version:        twitch.EventSubTopicVersion2,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorManageAutomod},
+			Hook:           t.handleEventSubAutomodMessage(eventTypeAutomodUpdate),
+			Optional:       true,
+		},
 		{
 			Topic:          twitch.EventSubEventTypeChannelAdBreakBegin,
 			Version:        twitch.EventSubTopicVersion1,
@@ -124,6 +186,13 @@ func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration
 			Hook:           t.handleEventSubChannelAdBreakBegin,
 			Optional:       true,
 		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelCheer,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeBitsRead},
+			Hook:           t.handleEventSubChannelCheer,
+			Optional:       true,
+		},
 		{
 			Topic:          twitch.EventSubEventTypeChannelFollow,
 			Version:        twitch.EventSubTopicVersion2,
@@ -188,6 +257,38 @@ func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration
 			Hook:           t.handleEventSubChannelPollChange(eventTypePollProgress),
 			Optional:       true,
 		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelPredictionBegin,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelPredictionChange(eventTypePredictionBegin),
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelPredictionEnd,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelPredictionChange(eventTypePredictionEnd),
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelPredictionLock,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelPredictionChange(eventTypePredictionLock),
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelPredictionProgress,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadPredictions, twitch.ScopeChannelManagePredictions},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelPredictionChange(eventTypePredictionProgress),
+			Optional:       true,
+		},
 		{
 			Topic:          twitch.EventSubEventTypeChannelRaid,
 			Condition:      twitch.EventSubCondition{FromBroadcasterUserID: userID},
@@ -195,6 +296,23 @@ func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration
 			Hook:           t.handleEventSubChannelOutboundRaid,
 			Optional:       true,
 		},
+		{
+			Topic:     twitch.EventSubEventTypeChannelModerate,
+			Version:   twitch.EventSubTopicVersion2,
+			Condition: twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			ScopeGroups: [][]string{
+				{twitch.ScopeModeratorReadBannedUsers, twitch.ScopeModeratorManageBannedUsers},
+				{twitch.ScopeModeratorReadBlockedTerms, twitch.ScopeModeratorManageBlockedTerms},
+				{twitch.ScopeModeratorReadChatMessages, twitch.ScopeModeratorManageChatMessages},
+				{twitch.ScopeModeratorReadChatSettings, twitch.ScopeModeratorManageChatSettings},
+				{twitch.ScopeModeratorReadModerators, twitch.ScopeChannelManageModerators},
+				{twitch.ScopeModeratorReadUnbanRequests, twitch.ScopeModeratorManageUnbanRequests},
+				{twitch.ScopeModeratorReadVIPs, twitch.ScopeChannelManageVIPS},
+				{twitch.ScopeModeratorReadWarnings, twitch.ScopeModeratorManageWarnings},
+			},
+			Hook:     t.handleEventSubChannelModerate,
+			Optional: true,
+		},
 		{
 			Topic:          twitch.EventSubEventTypeChannelShoutoutCreate,
 			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
@@ -211,6 +329,27 @@ func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration
 			Hook:           t.handleEventSubShoutoutReceived,
 			Optional:       true,
 		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelSubscribe,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadSubscriptions},
+			Hook:           t.handleEventSubChannelSubscribe,
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelSubscriptionGift,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadSubscriptions},
+			Hook:           t.handleEventSubChannelSubscriptionGift,
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelSubscriptionMessage,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID},
+			RequiredScopes: []string{twitch.ScopeChannelReadSubscriptions},
+			Hook:           t.handleEventSubChannelSubscriptionMessage,
+			Optional:       true,
+		},
 		{
 			Topic:          twitch.EventSubEventTypeChannelUpdate,
 			Version:        twitch.EventSubTopicVersion2,
@@ -247,6 +386,79 @@ func (t *twitchWatcher) getTopicRegistrations(userID string) []topicRegistration
 			Hook:           t.handleEventSubSusUserUpdate,
 			Optional:       true,
 		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelUnbanRequestCreate,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorReadUnbanRequests, twitch.ScopeModeratorManageUnbanRequests},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelUnbanRequestCreate,
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelUnbanRequestResolve,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorReadUnbanRequests, twitch.ScopeModeratorManageUnbanRequests},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelUnbanRequestResolve,
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelWarningAcknowledge,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorReadWarnings, twitch.ScopeModeratorManageWarnings},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelWarningAcknowledge,
+			Optional:       true,
+		},
+		{
+			Topic:          twitch.EventSubEventTypeChannelWarningSend,
+			Condition:      twitch.EventSubCondition{BroadcasterUserID: userID, ModeratorUserID: userID},
+			RequiredScopes: []string{twitch.ScopeModeratorReadWarnings, twitch.ScopeModeratorManageWarnings},
+			AnyScope:       true,
+			Hook:           t.handleEventSubChannelWarningSend,
+			Optional:       true,
+		},
+	}
+}
+
+func (*twitchWatcher) handleEventSubAutomodMessage(event *string) func(json.RawMessage) error {
+	return func(m json.RawMessage) error {
+		// The update payload is a superset of the hold payload so both
+		// can be handled using the same struct
+		var payload twitch.EventSubEventAutomodMessageUpdate
+		if err := json.Unmarshal(m, &payload); err != nil {
+			return fmt.Errorf("unmarshalling event: %w", err)
+		}
+
+		fields := fieldcollection.FromData(map[string]any{
+			"channel":    "#" + payload.BroadcasterUserLogin,
+			"held_at":    payload.HeldAt,
+			"message":    payload.Message.Text,
+			"message_id": payload.MessageID,
+			"reason":     payload.Reason,
+			"user_id":    payload.UserID,
+			"username":   payload.UserLogin,
+		})
+
+		if payload.Automod != nil {
+			fields.Set("category", payload.Automod.Category)
+			fields.Set("level", payload.Automod.Level)
+		}
+
+		if terms := payload.FoundTerms(); len(terms) > 0 {
+			fields.Set("terms", terms)
+		}
+
+		if event == eventTypeAutomodUpdate {
+			fields.Set("moderator", payload.ModeratorUserLogin)
+			fields.Set("moderator_id", payload.ModeratorUserID)
+			fields.Set("status", strings.ToLower(payload.Status))
+		}
+
+		log.WithFields(log.Fields(fields.Data())).Info("AutoMod held message event")
+		go handleMessage(ircHdl.Client(), nil, event, fields)
+
+		return nil
 	}
 }
 
@@ -269,6 +481,37 @@ func (*twitchWatcher) handleEventSubChannelAdBreakBegin(m json.RawMessage) error
 	return nil
 }
 
+func (*twitchWatcher) handleEventSubChannelCheer(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelCheer
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	user := payload.UserLogin
+	if payload.IsAnonymous {
+		// Same user IRC reports for anonymous cheers
+		user = "ananonymouscheerer"
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"bits":             payload.Bits,
+		eventFieldChannel:  "#" + payload.BroadcasterUserLogin,
+		"is_anonymous":     payload.IsAnonymous,
+		"message":          payload.Message,
+		eventFieldUserName: user,
+		eventFieldUserID:   payload.UserID,
+	})
+
+	if !eventDedup.ShouldEmit(eventSourceEventSub, *eventTypeBits, "#"+payload.BroadcasterUserLogin, user, strconv.FormatInt(payload.Bits, 10)) {
+		return nil
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("User spent bits")
+	go handleMessage(ircHdl.Client(), nil, eventTypeBits, fields)
+
+	return nil
+}
+
 func (*twitchWatcher) handleEventSubChannelFollow(m json.RawMessage) error {
 	var payload twitch.EventSubEventFollow
 	if err := json.Unmarshal(m, &payload); err != nil {
@@ -288,6 +531,74 @@ func (*twitchWatcher) handleEventSubChannelFollow(m json.RawMessage) error {
 	return nil
 }
 
+func (*twitchWatcher) handleEventSubChannelModerate(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelModerate
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"action":       payload.Action,
+		"channel":      "#" + payload.BroadcasterUserLogin,
+		"moderator":    payload.ModeratorUserLogin,
+		"moderator_id": payload.ModeratorUserID,
+	})
+
+	if payload.SourceBroadcasterUserLogin != nil {
+		fields.Set("source_channel", "#"+*payload.SourceBroadcasterUserLogin)
+	}
+
+	if target := payload.Target(); target != nil {
+		fields.Set("target_id", target.UserID)
+		fields.Set("target_name", target.UserLogin)
+
+		for key, value := range map[string]string{
+			"message":    target.MessageBody,
+			"message_id": target.MessageID,
+			"reason":     target.Reason,
+		} {
+			if value != "" {
+				fields.Set(key, value)
+			}
+		}
+
+		if target.ExpiresAt != nil {
+			fields.Set("expires_at", *target.ExpiresAt)
+			fields.Set("duration", int64(time.Until(*target.ExpiresAt).Round(time.Second)/time.Second))
+		}
+
+		if len(target.ChatRulesCited) > 0 {
+			fields.Set("rules_cited", target.ChatRulesCited)
+		}
+
+		if payload.Raid != nil {
+			fields.Set("viewers", payload.Raid.ViewerCount)
+		}
+	}
+
+	switch {
+	case payload.AutomodTerms != nil:
+		fields.Set("from_automod", payload.AutomodTerms.FromAutomod)
+		fields.Set("list", payload.AutomodTerms.List)
+		fields.Set("terms", payload.AutomodTerms.Terms)
+
+	case payload.Followers != nil:
+		fields.Set("follow_duration", payload.Followers.FollowDurationMinutes)
+
+	case payload.Slow != nil:
+		fields.Set("wait_time", payload.Slow.WaitTimeSeconds)
+
+	case payload.UnbanRequest != nil:
+		fields.Set("approved", payload.UnbanRequest.IsApproved)
+		fields.Set("reason", payload.UnbanRequest.ModeratorMessage)
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("Moderator action")
+	go handleMessage(ircHdl.Client(), nil, eventTypeModAction, fields)
+
+	return nil
+}
+
 func (*twitchWatcher) handleEventSubChannelOutboundRaid(m json.RawMessage) error {
 	var payload twitch.EventSubEventRaid
 	if err := json.Unmarshal(m, &payload); err != nil {
@@ -314,14 +625,15 @@ func (*twitchWatcher) handleEventSubChannelPointCustomRewardRedemptionAdd(m json
 	}
 
 	fields := fieldcollection.FromData(map[string]any{
-		"channel":      "#" + payload.BroadcasterUserLogin,
-		"reward_cost":  payload.Reward.Cost,
-		"reward_id":    payload.Reward.ID,
-		"reward_title": payload.Reward.Title,
-		"status":       payload.Status,
-		"user_id":      payload.UserID,
-		"user_input":   payload.UserInput,
-		"user":         payload.UserLogin,
+		"channel":       "#" + payload.BroadcasterUserLogin,
+		"redemption_id": payload.ID,
+		"reward_cost":   payload.Reward.Cost,
+		"reward_id":     payload.Reward.ID,
+		"reward_title":  payload.Reward.Title,
+		"status":        payload.Status,
+		"user_id":       payload.UserID,
+		"user_input":    payload.UserInput,
+		"user":          payload.UserLogin,
 	})
 
 	log.WithFields(log.Fields(fields.Data())).Info("ChannelPoint reward was redeemed")
@@ -367,6 +679,210 @@ func (*twitchWatcher) handleEventSubChannelPollChange(event *string) func(json.R
 	}
 }
 
+func (*twitchWatcher) handleEventSubChannelPredictionChange(event *string) func(json.RawMessage) error {
+	return func(m json.RawMessage) error {
+		var payload twitch.EventSubEventPrediction
+		if err := json.Unmarshal(m, &payload); err != nil {
+			return fmt.Errorf("unmarshalling event: %w", err)
+		}
+
+		fields := fieldcollection.FromData(map[string]any{
+			"channel": "#" + payload.BroadcasterUserLogin,
+			"title":   payload.Title,
+		})
+
+		logger := log.WithFields(log.Fields(fields.Data()))
+
+		switch event {
+		case eventTypePredictionBegin:
+			logger.Info("Prediction started")
+
+		case eventTypePredictionEnd:
+			fields.Set("status", payload.Status)
+			for _, o := range payload.Outcomes {
+				if o.ID == payload.WinningOutcomeID {
+					fields.Set("winning_outcome", o.Title)
+				}
+			}
+			logger.WithField("status", payload.Status).Info("Prediction ended")
+
+		case eventTypePredictionLock:
+			logger.Info("Prediction locked")
+
+		case eventTypePredictionProgress:
+			// Lets not spam the info-level-log with every single
+			// prediction but provide them for bots with debug-level-logging
+			logger.Debug("Prediction changed")
+		}
+
+		// Set after logging not to spam logs with full payload
+		fields.Set("prediction", payload)
+
+		go handleMessage(ircHdl.Client(), nil, event, fields)
+		return nil
+	}
+}
+
+func (*twitchWatcher) handleEventSubChannelSubscribe(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelSubscribe
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	if payload.IsGift {
+		// Gifted subs are announced as subgift through IRC: the payload
+		// lacks the gifter and everything else IRC reports for them
+		return nil
+	}
+
+	// The payload does not contain the multi-month duration, so unlike
+	// through IRC the multi_month field is not available
+	fields := fieldcollection.FromData(map[string]any{
+		eventFieldChannel:  "#" + payload.BroadcasterUserLogin,
+		"from":             payload.UserLogin,
+		"plan":             payload.Tier,
+		eventFieldUserName: payload.UserLogin,
+		eventFieldUserID:   payload.UserID,
+	})
+
+	if !eventDedup.ShouldEmit(eventSourceEventSub, *eventTypeSub, "#"+payload.BroadcasterUserLogin, payload.UserLogin) {
+		return nil
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("User subscribed")
+	go handleMessage(ircHdl.Client(), nil, eventTypeSub, fields)
+
+	return nil
+}
+
+func (*twitchWatcher) handleEventSubChannelSubscriptionGift(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelSubscriptionGift
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	from := payload.UserLogin
+	if payload.IsAnonymous {
+		// Same user IRC reports for anonymous gifts
+		from = "ananonymousgifter"
+	}
+
+	var totalGifted int64
+	if payload.CumulativeTotal != nil {
+		totalGifted = *payload.CumulativeTotal
+	}
+
+	if payload.Total < 2 {
+		// A single gift might either be gifted to a specific user or
+		// to the community which cannot be told apart here: it is
+		// announced as subgift through IRC which also carries the
+		// anonymity and the total number of gifts of the gifter
+		return nil
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		eventFieldChannel:  "#" + payload.BroadcasterUserLogin,
+		"from":             from,
+		"is_anonymous":     payload.IsAnonymous,
+		"number":           payload.Total,
+		"plan":             payload.Tier,
+		"total_gifted":     totalGifted,
+		eventFieldUserName: from,
+		eventFieldUserID:   payload.UserID,
+	})
+
+	if !eventDedup.ShouldEmit(eventSourceEventSub, *eventTypeSubmysterygift, "#"+payload.BroadcasterUserLogin, from) {
+		return nil
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("User gifted subs to the community")
+	go handleMessage(ircHdl.Client(), nil, eventTypeSubmysterygift, fields)
+
+	return nil
+}
+
+func (*twitchWatcher) handleEventSubChannelSubscriptionMessage(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelSubscriptionMessage
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		eventFieldChannel:   "#" + payload.BroadcasterUserLogin,
+		"from":              payload.UserLogin,
+		"message":           payload.Message.Text,
+		"multi_month":       payload.DurationMonths,
+		"plan":              payload.Tier,
+		"subscribed_months": payload.CumulativeMonths,
+		eventFieldUserName:  payload.UserLogin,
+		eventFieldUserID:    payload.UserID,
+	})
+
+	if payload.StreakMonths != nil {
+		fields.Set("streak_months", *payload.StreakMonths)
+	}
+
+	if !eventDedup.ShouldEmit(eventSourceEventSub, *eventTypeResub, "#"+payload.BroadcasterUserLogin, payload.UserLogin) {
+		return nil
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("User re-subscribed")
+	go handleMessage(ircHdl.Client(), nil, eventTypeResub, fields)
+
+	return nil
+}
+
+func (*twitchWatcher) handleEventSubChannelUnbanRequestCreate(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelUnbanRequestCreate
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"channel":    "#" + payload.BroadcasterUserLogin,
+		"created_at": payload.CreatedAt,
+		"message":    payload.Text,
+		"request_id": payload.ID,
+		"user_id":    payload.UserID,
+		"username":   payload.UserLogin,
+	})
+
+	log.WithFields(log.Fields(fields.Data())).Info("Unban request created")
+	go handleMessage(ircHdl.Client(), nil, eventTypeUnbanRequest, fields)
+
+	return nil
+}
+
+func (*twitchWatcher) handleEventSubChannelUnbanRequestResolve(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelUnbanRequestResolve
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"channel":    "#" + payload.BroadcasterUserLogin,
+		"request_id": payload.ID,
+		"status":     payload.Status,
+		"user_id":    payload.UserID,
+		"username":   payload.UserLogin,
+	})
+
+	for key, value := range map[string]*string{
+		"moderator":    payload.ModeratorUserLogin,
+		"moderator_id": payload.ModeratorUserID,
+		"resolution":   payload.ResolutionText,
+	} {
+		if value != nil {
+			fields.Set(key, *value)
+		}
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("Unban request resolved")
+	go handleMessage(ircHdl.Client(), nil, eventTypeUnbanResolved, fields)
+
+	return nil
+}
+
 func (t *twitchWatcher) handleEventSubChannelUpdate(m json.RawMessage) error {
 	var payload twitch.EventSubEventChannelUpdate
 	if err := json.Unmarshal(m, &payload); err != nil {
@@ -378,6 +894,52 @@ func (t *twitchWatcher) handleEventSubChannelUpdate(m json.RawMessage) error {
 	return nil
 }
 
+func (*twitchWatcher) handleEventSubChannelWarningAcknowledge(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelWarningAcknowledge
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"channel":  "#" + payload.BroadcasterUserLogin,
+		"user_id":  payload.UserID,
+		"username": payload.UserLogin,
+	})
+
+	log.WithFields(log.Fields(fields.Data())).Info("User acknowledged warning")
+	go handleMessage(ircHdl.Client(), nil, eventTypeWarnAcknowledge, fields)
+
+	return nil
+}
+
+func (*twitchWatcher) handleEventSubChannelWarningSend(m json.RawMessage) error {
+	var payload twitch.EventSubEventChannelWarningSend
+	if err := json.Unmarshal(m, &payload); err != nil {
+		return fmt.Errorf("unmarshalling event: %w", err)
+	}
+
+	fields := fieldcollection.FromData(map[string]any{
+		"channel":      "#" + payload.BroadcasterUserLogin,
+		"moderator":    payload.ModeratorUserLogin,
+		"moderator_id": payload.ModeratorUserID,
+		"target_id":    payload.UserID,
+		"target_name":  payload.UserLogin,
+	})
+
+	if payload.Reason != nil {
+		fields.Set("reason", *payload.Reason)
+	}
+
+	if len(payload.ChatRulesCited) > 0 {
+		fields.Set("rules_cited", payload.ChatRulesCited)
+	}
+
+	log.WithFields(log.Fields(fields.Data())).Info("User was warned")
+	go handleMessage(ircHdl.Client(), nil, eventTypeWarn, fields)
+
+	return nil
+}
+
 func (*twitchWatcher) handleEventSubHypetrainEvent(eventType *string) func(json.RawMessage) error {
 	return func(m json.RawMessage) error {
 		var payload twitch.EventSubEventHypetrain
@@ -496,7 +1058,7 @@ func (*twitchWatcher) handleEventSubSusUserUpdate(m json.RawMessage) (err error)
 	return nil
 }
 
-func (t *twitchWatcher) registerEventSubCallbacks(channel string) (*twitch.EventSubSocketClient, error) {
+func (t *twitchWatcher) registerEventSubCallbacks(channel string) (eventSubClient, error) {
 	tc, err := accessService.GetTwitchClientForChannel(channel, access.ClientConfig{
 		TwitchClient:       cfg.TwitchClient,
 		TwitchClientSecret: cfg.TwitchClientSecret,
@@ -517,14 +1079,16 @@ func (t *twitchWatcher) registerEventSubCallbacks(channel string) (*twitch.Event
 	var (
 		topicRegistrations = t.getTopicRegistrations(userID)
 		topicOpts          []twitch.EventSubSocketClientOpt
+		webhookOpts        []twitch.EventSubWebhookClientOpt
 	)
 
 	for _, tr := range topicRegistrations {
 		logger := log.WithFields(log.Fields{
-			"any":     tr.AnyScope,
-			"channel": channel,
-			"scopes":  tr.RequiredScopes,
-			"topic":   tr.Topic,
+			"any":          tr.AnyScope,
+			"channel":      channel,
+			"scopes":       tr.RequiredScopes,
+			"scope_groups": tr.ScopeGroups,
+			"topic":        tr.Topic,
 		})
 
 		if len(tr.RequiredScopes) > 0 {
@@ -544,14 +1108,41 @@ func (t *twitchWatcher) registerEventSubCallbacks(channel string) (*twitch.Event
 			}
 		}
 
-		var opt twitch.EventSubSocketClientOpt
+		hasScopeGroups := true
+		for _, group := range tr.ScopeGroups {
+			hasScopes, err := accessService.HasAnyPermissionForChannel(channel, group...)
+			if err != nil {
+				return nil, fmt.Errorf("checking granted scopes: %w", err)
+			}
+
+			if !hasScopes {
+				hasScopeGroups = false
+				break
+			}
+		}
+
+		if !hasScopeGroups {
+			logger.Debug("Missing scopes for eventsub topic")
+			continue
+		}
+
 		if tr.Optional {
-			opt = twitch.WithRetryBackgroundSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook)
+			topicOpts = append(topicOpts, twitch.WithRetryBackgroundSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook))
+			webhookOpts = append(webhookOpts, twitch.WithWebhookRetryBackgroundSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook))
 		} else {
-			opt = twitch.WithMustSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook)
+			topicOpts = append(topicOpts, twitch.WithMustSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook))
+			webhookOpts = append(webhookOpts, twitch.WithWebhookMustSubscribe(tr.Topic, tr.Version, tr.Condition, tr.Hook))
 		}
+	}
 
-		topicOpts = append(topicOpts, opt)
+	if eventSubWebhook != nil {
+		// Webhook subscriptions are created using the app-access-token
+		// of the handler, the channel-client is only used to verify
+		// the channel did authorize the bot
+		return eventSubWebhook.NewClient(append(
+			webhookOpts,
+			twitch.WithWebhookClientLogger(log.WithField("channel", channel)),
+		)...), nil
 	}
 
 	esClient, err := twitch.NewEventSubSocketClient(append(
@@ -653,12 +1244,16 @@ func (t *twitchWatcher) updateChannelFromAPI(channel string) error {
 
 	if storedStatus.esc != nil {
 		log.WithField("channel", channel).Info("watching for eventsub events")
-		go func(storedStatus *twitchChannelState) {
-			if err := storedStatus.esc.Run(); err != nil {
+		go func(storedStatus *twitchChannelState, esc eventSubClient) {
+			if err := esc.Run(); err != nil {
 				log.WithField("channel", channel).WithError(helpers.CleanNetworkAddressFromError(err)).Error("eventsub client caused error")
 			}
+
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
 			storedStatus.CloseESC()
-		}(storedStatus)
+		}(storedStatus, storedStatus.esc)
 	}
 
 	return nil
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Luzifer/go_helpers/fieldcollection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dispatchTestEventSubEvent feeds the payload into the hook and
+// returns the event and fields dispatched for the given channel
+func dispatchTestEventSubEvent(t *testing.T, hook func(json.RawMessage) error, channel, payload string) (string, *fieldcollection.FieldCollection) {
+	t.Helper()
+
+	type dispatchedEvent struct {
+		event  string
+		fields *fieldcollection.FieldCollection
+	}
+
+	dispatched := make(chan dispatchedEvent, 1)
+	require.NoError(t, registerEventHandlers(func(event string, eventData *fieldcollection.FieldCollection) error {
+		if eventData.MustString("channel", new("")) != channel {
+			return nil
+		}
+
+		select {
+		case dispatched <- dispatchedEvent{event, eventData}:
+		default:
+			// Handler of an earlier dispatch, nobody is listening
+		}
+		return nil
+	}))
+
+	// Config is not restored as the dispatched message might still be
+	// matched against the rules in the background
+	configLock.Lock()
+	if config == nil {
+		config = newConfigFile()
+	}
+	if ircHdl == nil {
+		ircHdl = &ircHandler{}
+	}
+	configLock.Unlock()
+
+	require.NoError(t, hook(json.RawMessage(payload)))
+
+	select {
+	case evt := <-dispatched:
+		return evt.event, evt.fields
+	case <-time.After(time.Second):
+		t.Fatal("event was not dispatched")
+		return "", nil
+	}
+}
+
+func TestHandleEventSubChannelPredictionChange(t *testing.T) {
+	w := &twitchWatcher{}
+
+	for name, tc := range map[string]struct {
+		event     *string
+		payload   string
+		expFields map[string]any
+	}{
+		"begin": {
+			eventTypePredictionBegin,
+			`{"broadcaster_user_login":"predictchannel","title":"Boss?","outcomes":[{"id":"o1","title":"Yes"},{"id":"o2","title":"No"}]}`,
+			map[string]any{"channel": "#predictchannel", "title": "Boss?"},
+		},
+		"lock": {
+			eventTypePredictionLock,
+			`{"broadcaster_user_login":"predictchannel","title":"Boss?"}`,
+			map[string]any{"channel": "#predictchannel", "title": "Boss?"},
+		},
+		"end resolved": {
+			eventTypePredictionEnd,
+			`{"broadcaster_user_login":"predictchannel","title":"Boss?","status":"resolved","winning_outcome_id":"o2","outcomes":[{"id":"o1","title":"Yes"},{"id":"o2","title":"No"}]}`,
+			map[string]any{"channel": "#predictchannel", "title": "Boss?", "status": "resolved", "winning_outcome": "No"},
+		},
+		"end canceled": {
+			eventTypePredictionEnd,
+			`{"broadcaster_user_login":"predictchannel","title":"Boss?","status":"canceled","outcomes":[{"id":"o1","title":"Yes"}]}`,
+			map[string]any{"channel": "#predictchannel", "title": "Boss?", "status": "canceled"},
+		},
+	} {
+		event, fields := dispatchTestEventSubEvent(t, w.handleEventSubChannelPredictionChange(tc.event), "#predictchannel", tc.payload)
+		assert.Equal(t, *tc.event, event, name)
+
+		prediction := fields.Data()["prediction"]
+		data := fields.Data()
+		delete(data, "prediction")
+
+		assert.Equal(t, tc.expFields, data, name)
+		assert.NotNil(t, prediction, name)
+	}
+}
+
+func TestHandleEventSubChannelPointCustomRewardRedemptionAdd(t *testing.T) {
+	event, fields := dispatchTestEventSubEvent(t, (&twitchWatcher{}).handleEventSubChannelPointCustomRewardRedemptionAdd, "#rewardchannel", `{
+		"id": "red1",
+		"broadcaster_user_login": "rewardchannel",
+		"user_id": "42",
+		"user_login": "amy",
+		"user_input": "hello",
+		"status": "unfulfilled",
+		"reward": {"id": "rew1", "title": "Hydrate", "cost": 100}
+	}`)
+
+	assert.Equal(t, *eventTypeChannelPointRedeem, event)
+	assert.Equal(t, map[string]any{
+		"channel":       "#rewardchannel",
+		"redemption_id": "red1",
+		"reward_cost":   int64(100),
+		"reward_id":     "rew1",
+		"reward_title":  "Hydrate",
+		"status":        "unfulfilled",
+		"user_id":       "42",
+		"user_input":    "hello",
+		"user":          "amy",
+	}, fields.Data())
+}
+
+func TestHandleEventSubChannelModerate(t *testing.T) {
+	w := &twitchWatcher{}
+	base := map[string]any{"channel": "#modchannel", "moderator": "mod", "moderator_id": "1"}
+
+	with := func(action string, extra map[string]any) map[string]any {
+		out := map[string]any{"action": action}
+		for k, v := range base {
+			out[k] = v
+		}
+		for k, v := range extra {
+			out[k] = v
+		}
+		return out
+	}
+
+	for name, tc := range map[string]struct {
+		payload   string
+		expFields map[string]any
+	}{
+		"ban": {
+			`{"action":"ban","ban":{"user_id":"2","user_login":"amy","reason":"spam"}}`,
+			with("ban", map[string]any{"target_id": "2", "target_name": "amy", "reason": "spam"}),
+		},
+		"delete": {
+			`{"action":"delete","delete":{"user_id":"2","user_login":"amy","message_id":"m1","message_body":"buy followers"}}`,
+			with("delete", map[string]any{"target_id": "2", "target_name": "amy", "message_id": "m1", "message": "buy followers"}),
+		},
+		"warn": {
+			`{"action":"warn","warn":{"user_id":"2","user_login":"amy","reason":"calm down","chat_rules_cited":["Be nice"]}}`,
+			with("warn", map[string]any{"target_id": "2", "target_name": "amy", "reason": "calm down", "rules_cited": []string{"Be nice"}}),
+		},
+		"raid": {
+			`{"action":"raid","raid":{"user_id":"3","user_login":"friend","viewer_count":12}}`,
+			with("raid", map[string]any{"target_id": "3", "target_name": "friend", "viewers": int64(12)}),
+		},
+		"unban request": {
+			`{"action":"deny_unban_request","unban_request":{"user_id":"2","user_login":"amy","is_approved":false,"moderator_message":"no"}}`,
+			with("deny_unban_request", map[string]any{"target_id": "2", "target_name": "amy", "approved": false, "reason": "no"}),
+		},
+		"shared chat ban": {
+			`{"action":"shared_chat_ban","source_broadcaster_user_login":"otherchannel","shared_chat_ban":{"user_id":"2","user_login":"amy"}}`,
+			with("shared_chat_ban", map[string]any{"target_id": "2", "target_name": "amy", "source_channel": "#otherchannel"}),
+		},
+		"slow": {
+			`{"action":"slow","slow":{"wait_time_seconds":30}}`,
+			with("slow", map[string]any{"wait_time": int64(30)}),
+		},
+		"followers": {
+			`{"action":"followers","followers":{"follow_duration_minutes":10}}`,
+			with("followers", map[string]any{"follow_duration": int64(10)}),
+		},
+		"blocked term": {
+			`{"action":"add_blocked_term","automod_terms":{"action":"add","list":"blocked","terms":["foo"],"from_automod":false}}`,
+			with("add_blocked_term", map[string]any{"from_automod": false, "list": "blocked", "terms": []string{"foo"}}),
+		},
+	} {
+		payload := `{"broadcaster_user_login":"modchannel","moderator_user_id":"1","moderator_user_login":"mod",` + tc.payload[1:]
+
+		event, fields := dispatchTestEventSubEvent(t, w.handleEventSubChannelModerate, "#modchannel", payload)
+		assert.Equal(t, *eventTypeModAction, event, name)
+		assert.Equal(t, tc.expFields, fields.Data(), name)
+	}
+
+	// Timeouts carry their expiry and the remaining duration
+	expiresAt := time.Now().Add(10 * time.Minute).UTC().Truncate(time.Second)
+	_, fields := dispatchTestEventSubEvent(t, w.handleEventSubChannelModerate, "#modchannel",
+		`{"broadcaster_user_login":"modchannel","action":"timeout","timeout":{"user_id":"2","user_login":"amy","expires_at":"`+expiresAt.Format(time.RFC3339)+`"}}`)
+
+	assert.Equal(t, "amy", fields.MustString("target_name", nil))
+	assert.Equal(t, expiresAt, fields.Data()["expires_at"].(time.Time).UTC())
+	assert.InDelta(t, 600, fields.MustInt64("duration", nil), 1)
+}
+
+func TestHandleEventSubAutomodMessage(t *testing.T) {
+	w := &twitchWatcher{}
+	heldAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		event     *string
+		payload   string
+		expFields map[string]any
+	}{
+		"hold by automod": {
+			eventTypeAutomodHold,
+			`{"reason":"automod","automod":{"category":"swearing","level":3}}`,
+			map[string]any{"reason": "automod", "category": "swearing", "level": int64(3)},
+		},
+		"hold by blocked term": {
+			eventTypeAutomodHold,
+			`{"reason":"blocked_term","blocked_term":{"terms_found":[{"term_id":"t1","boundary":{"start_pos":0,"end_pos":3}}]}}`,
+			map[string]any{"reason": "blocked_term", "terms": []string{"spam"}},
+		},
+		"update": {
+			eventTypeAutomodUpdate,
+			`{"reason":"automod","automod":{"category":"swearing","level":3},"moderator_user_id":"1","moderator_user_login":"mod","status":"Approved"}`,
+			map[string]any{"reason": "automod", "category": "swearing", "level": int64(3), "moderator": "mod", "moderator_id": "1", "status": "approved"},
+		},
+	} {
+		payload := `{"broadcaster_user_login":"automodchannel","user_id":"2","user_login":"amy","message_id":"m1",` +
+			`"message":{"text":"spam message"},"held_at":"` + heldAt.Format(time.RFC3339) + `",` + tc.payload[1:]
+
+		expFields := map[string]any{
+			"channel":    "#automodchannel",
+			"held_at":    heldAt,
+			"message":    "spam message",
+			"message_id": "m1",
+			"user_id":    "2",
+			"username":   "amy",
+		}
+		for k, v := range tc.expFields {
+			expFields[k] = v
+		}
+
+		event, fields := dispatchTestEventSubEvent(t, w.handleEventSubAutomodMessage(tc.event), "#automodchannel", payload)
+		assert.Equal(t, *tc.event, event, name)
+		assert.Equal(t, expFields, fields.Data(), name)
+	}
+}
+
+func TestHandleEventSubChannelWarning(t *testing.T) {
+	w := &twitchWatcher{}
+
+	for name, tc := range map[string]struct {
+		hook      func(json.RawMessage) error
+		payload   string
+		expEvent  *string
+		expFields map[string]any
+	}{
+		"send": {
+			w.handleEventSubChannelWarningSend,
+			`{"broadcaster_user_login":"warnchannel","moderator_user_id":"1","moderator_user_login":"mod","user_id":"2","user_login":"amy","reason":"be nice","chat_rules_cited":["Rule 1"]}`,
+			eventTypeWarn,
+			map[string]any{
+				"channel": "#warnchannel", "moderator": "mod", "moderator_id": "1", "target_id": "2", "target_name": "amy",
+				"reason": "be nice", "rules_cited": []string{"Rule 1"},
+			},
+		},
+		"send without reason": {
+			w.handleEventSubChannelWarningSend,
+			`{"broadcaster_user_login":"warnchannel","moderator_user_id":"1","moderator_user_login":"mod","user_id":"2","user_login":"amy","reason":null,"chat_rules_cited":null}`,
+			eventTypeWarn,
+			map[string]any{"channel": "#warnchannel", "moderator": "mod", "moderator_id": "1", "target_id": "2", "target_name": "amy"},
+		},
+		"acknowledge": {
+			w.handleEventSubChannelWarningAcknowledge,
+			`{"broadcaster_user_login":"warnchannel","user_id":"2","user_login":"amy"}`,
+			eventTypeWarnAcknowledge,
+			map[string]any{"channel": "#warnchannel", "user_id": "2", "username": "amy"},
+		},
+	} {
+		event, fields := dispatchTestEventSubEvent(t, tc.hook, "#warnchannel", tc.payload)
+		assert.Equal(t, *tc.expEvent, event, name)
+		assert.Equal(t, tc.expFields, fields.Data(), name)
+	}
+}
+
+func TestHandleEventSubChannelUnbanRequest(t *testing.T) {
+	w := &twitchWatcher{}
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		hook      func(json.RawMessage) error
+		payload   string
+		expEvent  *string
+		expFields map[string]any
+	}{
+		"create": {
+			w.handleEventSubChannelUnbanRequestCreate,
+			`{"id":"req1","broadcaster_user_login":"unbanchannel","user_id":"2","user_login":"amy","text":"sorry","created_at":"` + createdAt.Format(time.RFC3339) + `"}`,
+			eventTypeUnbanRequest,
+			map[string]any{"channel": "#unbanchannel", "created_at": createdAt, "message": "sorry", "request_id": "req1", "user_id": "2", "username": "amy"},
+		},
+		"resolve by moderator": {
+			w.handleEventSubChannelUnbanRequestResolve,
+			`{"id":"req1","broadcaster_user_login":"unbanchannel","moderator_id":"1","moderator_login":"mod","user_id":"2","user_login":"amy","resolution_text":"welcome back","status":"approved"}`,
+			eventTypeUnbanResolved,
+			map[string]any{
+				"channel": "#unbanchannel", "request_id": "req1", "status": "approved", "user_id": "2", "username": "amy",
+				"moderator": "mod", "moderator_id": "1", "resolution": "welcome back",
+			},
+		},
+		"canceled by user": {
+			w.handleEventSubChannelUnbanRequestResolve,
+			`{"id":"req1","broadcaster_user_login":"unbanchannel","moderator_id":null,"moderator_login":null,"user_id":"2","user_login":"amy","resolution_text":null,"status":"canceled"}`,
+			eventTypeUnbanResolved,
+			map[string]any{"channel": "#unbanchannel", "request_id": "req1", "status": "canceled", "user_id": "2", "username": "amy"},
+		},
+	} {
+		event, fields := dispatchTestEventSubEvent(t, tc.hook, "#unbanchannel", tc.payload)
+		assert.Equal(t, *tc.expEvent, event, name)
+		assert.Equal(t, tc.expFields, fields.Data(), name)
+	}
+}